@@ -29,6 +29,11 @@ type Complex interface {
 	~complex64 | ~complex128
 }
 
+// Number is a constraint that permits any integer or floating-point type.
+type Number interface {
+	Integer | Float
+}
+
 // Ordered is a constraint that permits any ordered type: any type that supports the operators < <= >= >.
 type Ordered interface {
 	~int | ~int8 | ~int16 | ~int32 | ~int64 |
@@ -1,3 +1,3 @@
 module github.com/syumai/go-generics
 
-go 1.17
+go 1.18
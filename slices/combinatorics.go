@@ -0,0 +1,150 @@
+package slices
+
+// Permutations returns all len(s)! orderings of the elements of s, each in
+// a newly allocated slice. The number of results grows factorially: 10
+// elements already give over three million slices, so prefer
+// EachPermutation for anything but small inputs. The first permutation is
+// s itself, and an empty s has exactly one, empty, permutation.
+func Permutations[T any](s []T) [][]T {
+	var ps [][]T
+	EachPermutation(s, func(p []T) bool {
+		ps = append(ps, Clone(p))
+		return true
+	})
+	return ps
+}
+
+// EachPermutation calls f with each ordering of the elements of s in turn,
+// generated by Heap's algorithm, until f returns false. s is not modified;
+// f receives a single working slice that is reordered between calls, so it
+// must copy the slice to retain it.
+func EachPermutation[T any](s []T, f func([]T) bool) {
+	p := Clone(s)
+	if !f(p) {
+		return
+	}
+	// c[i] counts the swaps made at level i of the recursive formulation.
+	c := make([]int, len(p))
+	for i := 1; i < len(p); {
+		if c[i] < i {
+			if i%2 == 0 {
+				p[0], p[i] = p[i], p[0]
+			} else {
+				p[c[i]], p[i] = p[i], p[c[i]]
+			}
+			if !f(p) {
+				return
+			}
+			c[i]++
+			i = 1
+		} else {
+			c[i] = 0
+			i++
+		}
+	}
+}
+
+// Combinations returns all k-element subsets of s, each in a newly
+// allocated slice, in lexicographic order of their indices in s: for
+// s = [a b c] and k = 2 they are [a b], [a c], [b c]. If k is 0 the result
+// holds one empty combination, and if k exceeds len(s) it is empty.
+// Combinations panics if k is negative.
+func Combinations[T any](s []T, k int) [][]T {
+	if k < 0 {
+		panic("slices.Combinations: negative size")
+	}
+	var cs [][]T
+	EachCombination(s, k, func(c []T) bool {
+		cs = append(cs, Clone(c))
+		return true
+	})
+	return cs
+}
+
+// EachCombination calls f with each k-element subset of s in the order
+// used by Combinations, until f returns false. f receives a single working
+// slice that is overwritten between calls, so it must copy the slice to
+// retain it. EachCombination panics if k is negative.
+func EachCombination[T any](s []T, k int, f func([]T) bool) {
+	if k < 0 {
+		panic("slices.EachCombination: negative size")
+	}
+	n := len(s)
+	if k > n {
+		return
+	}
+	idx := make([]int, k)
+	c := make([]T, k)
+	for i := range idx {
+		idx[i] = i
+		c[i] = s[i]
+	}
+	for {
+		if !f(c) {
+			return
+		}
+		// Find the rightmost index that can still be advanced.
+		i := k - 1
+		for i >= 0 && idx[i] == n-k+i {
+			i--
+		}
+		if i < 0 {
+			return
+		}
+		idx[i]++
+		c[i] = s[idx[i]]
+		for j := i + 1; j < k; j++ {
+			idx[j] = idx[j-1] + 1
+			c[j] = s[idx[j]]
+		}
+	}
+}
+
+// CartesianProduct returns every way of taking one element from each of
+// ss, each in a newly allocated slice of len(ss) elements, in odometer
+// order: the last input varies fastest. If any input is empty the product
+// is empty; with no inputs at all it holds one empty slice.
+func CartesianProduct[T any](ss ...[]T) [][]T {
+	var ps [][]T
+	EachCartesianProduct(ss, func(p []T) bool {
+		ps = append(ps, Clone(p))
+		return true
+	})
+	return ps
+}
+
+// EachCartesianProduct calls f with each element of the Cartesian product
+// of ss in the order used by CartesianProduct, until f returns false.
+// f receives a single working slice that is overwritten between calls, so
+// it must copy the slice to retain it.
+func EachCartesianProduct[T any](ss [][]T, f func([]T) bool) {
+	for _, s := range ss {
+		if len(s) == 0 {
+			return
+		}
+	}
+	idx := make([]int, len(ss))
+	p := make([]T, len(ss))
+	for i, s := range ss {
+		p[i] = s[0]
+	}
+	for {
+		if !f(p) {
+			return
+		}
+		// Advance the odometer, carrying into earlier positions.
+		i := len(ss) - 1
+		for ; i >= 0; i-- {
+			idx[i]++
+			if idx[i] < len(ss[i]) {
+				p[i] = ss[i][idx[i]]
+				break
+			}
+			idx[i] = 0
+			p[i] = ss[i][0]
+		}
+		if i < 0 {
+			return
+		}
+	}
+}
@@ -0,0 +1,171 @@
+package slices
+
+import (
+	"fmt"
+	"testing"
+)
+
+func TestPermutations(t *testing.T) {
+	factorial := 1
+	for n := 0; n <= 5; n++ {
+		if n > 0 {
+			factorial *= n
+		}
+		s := make([]int, n)
+		for i := range s {
+			s[i] = i
+		}
+		ps := Permutations(s)
+		if len(ps) != factorial {
+			t.Errorf("Permutations(%v) returned %d permutations, want %d", s, len(ps), factorial)
+		}
+		seen := make(map[string]bool)
+		for _, p := range ps {
+			if !EqualUnordered(p, s) {
+				t.Errorf("Permutations(%v) returned %v, not a permutation", s, p)
+			}
+			key := fmt.Sprint(p)
+			if seen[key] {
+				t.Errorf("Permutations(%v) returned %v twice", s, p)
+			}
+			seen[key] = true
+		}
+		if !Equal(ps[0], s) {
+			t.Errorf("Permutations(%v)[0] = %v, want s itself", s, ps[0])
+		}
+		if n > 0 {
+			ps[0][0] = -1
+			if s[0] != 0 {
+				t.Errorf("Permutations aliases its input")
+			}
+		}
+	}
+}
+
+func TestEachPermutationStop(t *testing.T) {
+	s := []int{1, 2, 3, 4}
+	calls := 0
+	EachPermutation(s, func(p []int) bool {
+		calls++
+		return calls < 5
+	})
+	if calls != 5 {
+		t.Errorf("EachPermutation called f %d times, want 5", calls)
+	}
+	if !Equal(s, []int{1, 2, 3, 4}) {
+		t.Errorf("EachPermutation modified its input: %v", s)
+	}
+}
+
+func binomial(n, k int) int {
+	if k < 0 || k > n {
+		return 0
+	}
+	b := 1
+	for i := 1; i <= k; i++ {
+		b = b * (n - k + i) / i
+	}
+	return b
+}
+
+func TestCombinations(t *testing.T) {
+	for n := 0; n <= 6; n++ {
+		s := make([]int, n)
+		for i := range s {
+			s[i] = i
+		}
+		for k := 0; k <= n+1; k++ {
+			cs := Combinations(s, k)
+			if len(cs) != binomial(n, k) {
+				t.Errorf("Combinations(%v, %d) returned %d combinations, want %d", s, k, len(cs), binomial(n, k))
+			}
+			for i, c := range cs {
+				if len(c) != k || !IsSorted(c) {
+					t.Errorf("Combinations(%v, %d)[%d] = %v", s, k, i, c)
+				}
+				if i > 0 && Compare(cs[i-1], c) >= 0 {
+					t.Errorf("Combinations(%v, %d) not in lexicographic order: %v before %v", s, k, cs[i-1], c)
+				}
+			}
+		}
+	}
+
+	s := []string{"a", "b", "c"}
+	want := [][]string{{"a", "b"}, {"a", "c"}, {"b", "c"}}
+	cs := Combinations(s, 2)
+	if !EqualFunc(cs, want, Equal[string]) {
+		t.Errorf("Combinations(%v, 2) = %v, want %v", s, cs, want)
+	}
+	cs[0][0] = "x"
+	if s[0] != "a" || cs[1][0] != "a" {
+		t.Errorf("combinations alias each other or the input")
+	}
+
+	if cs := Combinations(s, 0); len(cs) != 1 || len(cs[0]) != 0 {
+		t.Errorf("Combinations(%v, 0) = %v, want [[]]", s, cs)
+	}
+}
+
+func TestEachCombinationStop(t *testing.T) {
+	calls := 0
+	EachCombination([]int{1, 2, 3, 4}, 2, func(c []int) bool {
+		calls++
+		return calls < 3
+	})
+	if calls != 3 {
+		t.Errorf("EachCombination called f %d times, want 3", calls)
+	}
+}
+
+func TestCombinationsPanics(t *testing.T) {
+	defer func() {
+		if recover() == nil {
+			t.Errorf("Combinations with negative k did not panic")
+		}
+	}()
+	Combinations([]int{1}, -1)
+}
+
+func TestCartesianProduct(t *testing.T) {
+	for _, tt := range []struct {
+		name string
+		ss   [][]string
+		want [][]string
+	}{
+		{"none", nil, [][]string{{}}},
+		{"single", [][]string{{"a", "b"}}, [][]string{{"a"}, {"b"}}},
+		{"empty input", [][]string{{"a", "b"}, {}, {"x"}}, nil},
+		{
+			"odometer",
+			[][]string{{"a", "b"}, {"x", "y", "z"}},
+			[][]string{{"a", "x"}, {"a", "y"}, {"a", "z"}, {"b", "x"}, {"b", "y"}, {"b", "z"}},
+		},
+		{
+			"three",
+			[][]string{{"a"}, {"x", "y"}, {"1", "2"}},
+			[][]string{{"a", "x", "1"}, {"a", "x", "2"}, {"a", "y", "1"}, {"a", "y", "2"}},
+		},
+	} {
+		got := CartesianProduct(tt.ss...)
+		if !EqualFunc(got, tt.want, Equal[string]) {
+			t.Errorf("%s: CartesianProduct(%v) = %v, want %v", tt.name, tt.ss, got, tt.want)
+		}
+	}
+
+	ps := CartesianProduct([]int{1, 2}, []int{3})
+	ps[0][0] = 10
+	if ps[1][0] != 2 {
+		t.Errorf("CartesianProduct results alias each other")
+	}
+}
+
+func TestEachCartesianProductStop(t *testing.T) {
+	calls := 0
+	EachCartesianProduct([][]int{{1, 2, 3}, {4, 5, 6}}, func(p []int) bool {
+		calls++
+		return calls < 4
+	})
+	if calls != 4 {
+		t.Errorf("EachCartesianProduct called f %d times, want 4", calls)
+	}
+}
@@ -0,0 +1,171 @@
+package slices
+
+// EditOp is the kind of operation recorded by an Edit.
+type EditOp int
+
+const (
+	// EditKeep leaves an element common to both slices in place.
+	EditKeep EditOp = iota
+	// EditInsert inserts an element of the second slice.
+	EditInsert
+	// EditDelete deletes an element of the first slice.
+	EditDelete
+)
+
+// String returns "keep", "insert" or "delete".
+func (op EditOp) String() string {
+	switch op {
+	case EditKeep:
+		return "keep"
+	case EditInsert:
+		return "insert"
+	case EditDelete:
+		return "delete"
+	}
+	return "EditOp(?)"
+}
+
+// Edit is one step of an edit script turning a slice a into a slice b, as
+// returned by Diff. A and B are the positions of the step in a and b:
+// an EditKeep has Value == a[A] == b[B], an EditDelete removes a[A] and an
+// EditInsert adds b[B]. For an insertion, A is the index of the next
+// element of a still to come, and for a deletion, B is the index of the
+// next element of b, so A and B never decrease along the script.
+type Edit[T any] struct {
+	Op    EditOp
+	Value T
+	A, B  int
+}
+
+// Diff returns a shortest edit script turning a into b: a sequence of
+// edits that keeps a longest common subsequence of a and b and deletes or
+// inserts everything else. Walking the script and taking the Value of each
+// EditKeep and EditInsert reproduces b.
+// Diff uses Myers' linear-space algorithm: for inputs of total length n
+// that differ in d elements it runs in O(n*d) time and O(n) extra space,
+// so diffing long, mostly equal slices is cheap, while completely
+// different inputs cost time quadratic in their length.
+func Diff[T comparable](a, b []T) []Edit[T] {
+	return DiffFunc(a, b, func(x, y T) bool { return x == y })
+}
+
+// DiffFunc is like Diff but uses eq to decide whether two elements are
+// equal. The Value of an EditKeep is the element of a.
+func DiffFunc[T any](a, b []T, eq func(T, T) bool) []Edit[T] {
+	d := differ[T]{a: a, b: b, eq: eq}
+	n := len(a) + len(b)
+	d.vf = make([]int, n+4)
+	d.vb = make([]int, n+4)
+	d.edits = make([]Edit[T], 0, len(a)+len(b))
+	d.diff(0, len(a), 0, len(b))
+	return d.edits
+}
+
+// differ holds the state of a single DiffFunc call: the inputs, the
+// forward and backward furthest-reaching diagonals reused by every
+// middleSnake call, and the edit script built so far.
+type differ[T any] struct {
+	a, b   []T
+	eq     func(T, T) bool
+	vf, vb []int
+	edits  []Edit[T]
+}
+
+// diff appends the edit script turning a[aLo:aHi] into b[bLo:bHi].
+func (d *differ[T]) diff(aLo, aHi, bLo, bHi int) {
+	// Strip the common prefix and suffix, which are kept unchanged.
+	for aLo < aHi && bLo < bHi && d.eq(d.a[aLo], d.b[bLo]) {
+		d.keep(aLo, bLo)
+		aLo++
+		bLo++
+	}
+	n := 0
+	for aLo < aHi-n && bLo < bHi-n && d.eq(d.a[aHi-1-n], d.b[bHi-1-n]) {
+		n++
+	}
+	aHi -= n
+	bHi -= n
+
+	switch {
+	case aLo == aHi:
+		for j := bLo; j < bHi; j++ {
+			d.edits = append(d.edits, Edit[T]{EditInsert, d.b[j], aLo, j})
+		}
+	case bLo == bHi:
+		for i := aLo; i < aHi; i++ {
+			d.edits = append(d.edits, Edit[T]{EditDelete, d.a[i], i, bLo})
+		}
+	default:
+		x, y, u, v := d.middleSnake(aLo, aHi, bLo, bHi)
+		d.diff(aLo, x, bLo, y)
+		for ; x < u; x, y = x+1, y+1 {
+			d.keep(x, y)
+		}
+		d.diff(u, aHi, v, bHi)
+	}
+
+	for i := 0; i < n; i++ {
+		d.keep(aHi+i, bHi+i)
+	}
+}
+
+func (d *differ[T]) keep(i, j int) {
+	d.edits = append(d.edits, Edit[T]{EditKeep, d.a[i], i, j})
+}
+
+// middleSnake finds the middle snake of a shortest edit path from
+// (aLo, bLo) to (aHi, bHi) by searching forward from the start and
+// backward from the end at the same time, and returns its endpoints
+// (x, y) and (u, v). The two halves of the path on either side of the
+// snake each need at most half as many edits as the whole, which bounds
+// the recursion in diff.
+func (d *differ[T]) middleSnake(aLo, aHi, bLo, bHi int) (x, y, u, v int) {
+	n, m := aHi-aLo, bHi-bLo
+	delta := n - m
+	odd := delta&1 != 0
+	// vf[off+k] is the furthest x reached on diagonal k = x-y going
+	// forward; vb[off+k] is the same going backward from (n, m), with
+	// x and y counted from the end.
+	off := (n+m+1)/2 + 1
+	vf, vb := d.vf[:2*off+1], d.vb[:2*off+1]
+	vf[off+1], vb[off+1] = 0, 0
+	for D := 0; D <= (n+m+1)/2; D++ {
+		for k := -D; k <= D; k += 2 {
+			var x int
+			if k == -D || (k != D && vf[off+k-1] < vf[off+k+1]) {
+				x = vf[off+k+1]
+			} else {
+				x = vf[off+k-1] + 1
+			}
+			y := x - k
+			x0, y0 := x, y
+			for x < n && y < m && d.eq(d.a[aLo+x], d.b[bLo+y]) {
+				x++
+				y++
+			}
+			vf[off+k] = x
+			if kb := delta - k; odd && kb >= -(D-1) && kb <= D-1 && x+vb[off+kb] >= n {
+				return aLo + x0, bLo + y0, aLo + x, bLo + y
+			}
+		}
+		for k := -D; k <= D; k += 2 {
+			var x int
+			if k == -D || (k != D && vb[off+k-1] < vb[off+k+1]) {
+				x = vb[off+k+1]
+			} else {
+				x = vb[off+k-1] + 1
+			}
+			y := x - k
+			x0, y0 := x, y
+			for x < n && y < m && d.eq(d.a[aHi-1-x], d.b[bHi-1-y]) {
+				x++
+				y++
+			}
+			vb[off+k] = x
+			if kf := delta - k; !odd && kf >= -D && kf <= D && x+vf[off+kf] >= n {
+				return aHi - x, bHi - y, aHi - x0, bHi - y0
+			}
+		}
+	}
+	panic("slices.Diff: no middle snake found")
+}
@@ -0,0 +1,172 @@
+package slices
+
+import (
+	"math/rand"
+	"testing"
+)
+
+// applyEdits replays an edit script against a, checking that it is
+// consistent with a, and returns the resulting slice.
+func applyEdits[T comparable](t *testing.T, a []T, edits []Edit[T]) []T {
+	t.Helper()
+	var b []T
+	i := 0
+	for _, e := range edits {
+		if e.A != i || e.B != len(b) {
+			t.Fatalf("edit %+v at a[%d], b[%d]", e, i, len(b))
+		}
+		switch e.Op {
+		case EditKeep:
+			if a[i] != e.Value {
+				t.Fatalf("keep %+v of a[%d] = %v", e, i, a[i])
+			}
+			b = append(b, e.Value)
+			i++
+		case EditDelete:
+			if a[i] != e.Value {
+				t.Fatalf("delete %+v of a[%d] = %v", e, i, a[i])
+			}
+			i++
+		case EditInsert:
+			b = append(b, e.Value)
+		}
+	}
+	if i != len(a) {
+		t.Fatalf("edit script consumed %d of %d elements of a", i, len(a))
+	}
+	return b
+}
+
+// lcsLen returns the length of a longest common subsequence of a and b.
+func lcsLen[T comparable](a, b []T) int {
+	prev := make([]int, len(b)+1)
+	cur := make([]int, len(b)+1)
+	for i := range a {
+		for j := range b {
+			switch {
+			case a[i] == b[j]:
+				cur[j+1] = prev[j] + 1
+			case prev[j+1] > cur[j]:
+				cur[j+1] = prev[j+1]
+			default:
+				cur[j+1] = cur[j]
+			}
+		}
+		prev, cur = cur, prev
+	}
+	return prev[len(b)]
+}
+
+func countKeeps[T any](edits []Edit[T]) int {
+	return CountFunc(edits, func(e Edit[T]) bool { return e.Op == EditKeep })
+}
+
+func TestDiff(t *testing.T) {
+	for _, tt := range []struct {
+		a, b string
+		want []EditOp
+	}{
+		{"", "", []EditOp{}},
+		{"abc", "abc", []EditOp{EditKeep, EditKeep, EditKeep}},
+		{"", "ab", []EditOp{EditInsert, EditInsert}},
+		{"ab", "", []EditOp{EditDelete, EditDelete}},
+		{"abc", "abxc", []EditOp{EditKeep, EditKeep, EditInsert, EditKeep}},
+		{"abxc", "abc", []EditOp{EditKeep, EditKeep, EditDelete, EditKeep}},
+	} {
+		a, b := []byte(tt.a), []byte(tt.b)
+		edits := Diff(a, b)
+		if got := Map(edits, func(e Edit[byte]) EditOp { return e.Op }); !Equal(got, tt.want) {
+			t.Errorf("Diff(%q, %q) ops = %v, want %v", tt.a, tt.b, got, tt.want)
+		}
+		if got := applyEdits(t, a, edits); string(got) != tt.b {
+			t.Errorf("applying Diff(%q, %q) = %q", tt.a, tt.b, got)
+		}
+	}
+
+	edits := Diff([]int{1, 2, 3}, []int{4, 5})
+	if countKeeps(edits) != 0 || len(edits) != 5 {
+		t.Errorf("Diff of disjoint slices = %v, want 3 deletes and 2 inserts", edits)
+	}
+}
+
+func TestDiffRandom(t *testing.T) {
+	r := rand.New(rand.NewSource(1))
+	for iter := 0; iter < 1000; iter++ {
+		a := make([]int, r.Intn(30))
+		b := make([]int, r.Intn(30))
+		alphabet := 1 + r.Intn(5)
+		for i := range a {
+			a[i] = r.Intn(alphabet)
+		}
+		for i := range b {
+			b[i] = r.Intn(alphabet)
+		}
+		edits := Diff(a, b)
+		if got := applyEdits(t, a, edits); !Equal(got, b) {
+			t.Fatalf("applying Diff(%v, %v) = %v", a, b, got)
+		}
+		if got, want := countKeeps(edits), lcsLen(a, b); got != want {
+			t.Fatalf("Diff(%v, %v) keeps %d elements, want %d", a, b, got, want)
+		}
+	}
+}
+
+func TestDiffFunc(t *testing.T) {
+	a := []point{{1, 0}, {2, 0}, {3, 0}}
+	b := []point{{1, 9}, {3, 9}}
+	edits := DiffFunc(a, b, func(p, q point) bool { return p.X == q.X })
+	want := []Edit[point]{
+		{EditKeep, point{1, 0}, 0, 0},
+		{EditDelete, point{2, 0}, 1, 1},
+		{EditKeep, point{3, 0}, 2, 1},
+	}
+	if !Equal(edits, want) {
+		t.Errorf("DiffFunc = %v, want %v", edits, want)
+	}
+}
+
+// TestDiffLarge checks that a few thousand elements with scattered changes
+// are diffed correctly and without the quadratic cost of a full LCS table.
+func TestDiffLarge(t *testing.T) {
+	a, b := diffBenchmarkInput(5000, 50)
+	edits := Diff(a, b)
+	if got := applyEdits(t, a, edits); !Equal(got, b) {
+		t.Fatalf("applying Diff to large input did not reproduce b")
+	}
+	if n := len(edits) - countKeeps(edits); n > 100 {
+		t.Errorf("Diff of large input used %d edits, want at most 100", n)
+	}
+}
+
+// diffBenchmarkInput returns a slice of n elements and a copy of it with
+// changes random elements replaced.
+func diffBenchmarkInput(n, changes int) (a, b []int) {
+	r := rand.New(rand.NewSource(1))
+	a = make([]int, n)
+	for i := range a {
+		a[i] = r.Int()
+	}
+	b = Clone(a)
+	for i := 0; i < changes; i++ {
+		b[r.Intn(n)] = r.Int()
+	}
+	return a, b
+}
+
+func BenchmarkDiff(b *testing.B) {
+	for _, bm := range []struct {
+		name       string
+		n, changes int
+	}{
+		{"Equal", 5000, 0},
+		{"FewChanges", 5000, 50},
+		{"ManyChanges", 5000, 2500},
+	} {
+		s1, s2 := diffBenchmarkInput(bm.n, bm.changes)
+		b.Run(bm.name, func(b *testing.B) {
+			for i := 0; i < b.N; i++ {
+				Diff(s1, s2)
+			}
+		})
+	}
+}
@@ -0,0 +1,109 @@
+package slices
+
+import (
+	"math"
+
+	"github.com/syumai/go-generics/constraints"
+)
+
+// Sum returns the sum of the elements of s, or 0 if s is empty.
+// Integer sums wrap around on overflow, following the usual Go semantics
+// for signed and unsigned arithmetic. Floating-point elements are added
+// from left to right without any error compensation.
+func Sum[T constraints.Number](s []T) T {
+	var sum T
+	for _, v := range s {
+		sum += v
+	}
+	return sum
+}
+
+// SumFunc returns the sum of f(e) over the elements e of s, or 0 if s is
+// empty. The same overflow and rounding rules as for Sum apply.
+func SumFunc[T any, N constraints.Number](s []T, f func(T) N) N {
+	var sum N
+	for _, v := range s {
+		sum += f(v)
+	}
+	return sum
+}
+
+// Product returns the product of the elements of s, or 1 if s is empty.
+// Integer products wrap around on overflow; detecting that is up to the
+// caller. Product does not stop early at a zero element, because for
+// floating-point numbers 0 * Inf and 0 * NaN are NaN rather than 0.
+func Product[T constraints.Number](s []T) T {
+	prod := T(1)
+	for _, v := range s {
+		prod *= v
+	}
+	return prod
+}
+
+// Mean returns the arithmetic mean of the elements of s as a float64, or NaN
+// if s is empty. Each element is converted to float64 before it is added, so
+// integer elements cannot overflow the sum.
+func Mean[T constraints.Number](s []T) float64 {
+	if len(s) == 0 {
+		return math.NaN()
+	}
+	sum := 0.0
+	for _, v := range s {
+		sum += float64(v)
+	}
+	return sum / float64(len(s))
+}
+
+// Median returns the median of the elements of s as a float64: the middle
+// element if len(s) is odd, or the mean of the two middle elements if it
+// is even. It is Percentile(s, 50).
+func Median[T constraints.Number](s []T) float64 {
+	return Percentile(s, 50)
+}
+
+// Percentile returns the p-th percentile of the elements of s as a
+// float64, for p between 0 and 100, interpolating linearly between the two
+// closest ranks: p = 0 gives the minimum and p = 100 the maximum.
+// s is not modified; the elements are converted to float64 and selected
+// from a copy with SelectNthInPlace, in O(len(s)) expected time.
+// Percentile returns NaN if s is empty or contains a NaN, and panics if p
+// is outside [0, 100] or is NaN.
+func Percentile[T constraints.Number](s []T, p float64) float64 {
+	if !(p >= 0 && p <= 100) {
+		panic("slices.Percentile: p out of range")
+	}
+	if len(s) == 0 {
+		return math.NaN()
+	}
+	f := make([]float64, len(s))
+	for i, v := range s {
+		f[i] = float64(v)
+		if math.IsNaN(f[i]) {
+			return math.NaN()
+		}
+	}
+	rank := p / 100 * float64(len(f)-1)
+	lo := int(rank)
+	v := SelectNthInPlace(f, lo)
+	if frac := rank - float64(lo); frac > 0 {
+		// After selection, the next rank is the least element above lo.
+		next := Min(f[lo+1:])
+		v += frac * (next - v)
+	}
+	return v
+}
+
+// Accumulate returns the prefix sums of s: a new slice r of the same length
+// with r[i] = s[0] + ... + s[i].
+func Accumulate[T constraints.Number](s []T) []T {
+	return AccumulateInPlace(Clone(s))
+}
+
+// AccumulateInPlace is like Accumulate, but overwrites s with its prefix
+// sums instead of allocating a new slice, and returns s.
+func AccumulateInPlace[T constraints.Number](s []T) []T {
+	for i := 1; i < len(s); i++ {
+		s[i] += s[i-1]
+	}
+	return s
+}
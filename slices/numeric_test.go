@@ -0,0 +1,226 @@
+package slices
+
+import (
+	"math"
+	"math/rand"
+	"testing"
+)
+
+func TestSum(t *testing.T) {
+	if got := Sum([]int{1, 2, 3, 4}); got != 10 {
+		t.Errorf("Sum(ints) = %d, want 10", got)
+	}
+	if got := Sum([]int(nil)); got != 0 {
+		t.Errorf("Sum(nil) = %d, want 0", got)
+	}
+	if got := Sum([]float64{0.5, 0.25, 0.125}); got != 0.875 {
+		t.Errorf("Sum(floats) = %v, want 0.875", got)
+	}
+	// Integer sums wrap around.
+	if got := Sum([]uint8{200, 100}); got != 44 {
+		t.Errorf("Sum(uint8{200, 100}) = %d, want 44", got)
+	}
+	if got := Sum([]int8{math.MaxInt8, 1}); got != math.MinInt8 {
+		t.Errorf("Sum(int8{MaxInt8, 1}) = %d, want %d", got, math.MinInt8)
+	}
+	type Cents int64
+	if got := Sum([]Cents{150, 250}); got != 400 {
+		t.Errorf("Sum(Cents) = %d, want 400", got)
+	}
+}
+
+func TestSumFunc(t *testing.T) {
+	type order struct {
+		ID    int
+		Total float64
+	}
+	orders := []order{{1, 9.5}, {2, 0.5}, {3, 10}}
+	if got := SumFunc(orders, func(o order) float64 { return o.Total }); got != 20 {
+		t.Errorf("SumFunc(Total) = %v, want 20", got)
+	}
+	if got := SumFunc([]order(nil), func(o order) int { return o.ID }); got != 0 {
+		t.Errorf("SumFunc(nil) = %v, want 0", got)
+	}
+}
+
+func BenchmarkSum(b *testing.B) {
+	data := make([]int, 10000)
+	for i := range data {
+		data[i] = i
+	}
+	b.Run("Sum", func(b *testing.B) {
+		for i := 0; i < b.N; i++ {
+			Sum(data)
+		}
+	})
+	b.Run("Loop", func(b *testing.B) {
+		for i := 0; i < b.N; i++ {
+			sum := 0
+			for _, v := range data {
+				sum += v
+			}
+			_ = sum
+		}
+	})
+	b.Run("SumFunc", func(b *testing.B) {
+		for i := 0; i < b.N; i++ {
+			SumFunc(data, func(v int) int { return v })
+		}
+	})
+}
+
+func TestProduct(t *testing.T) {
+	if got := Product([]int{1, 2, 3, 4}); got != 24 {
+		t.Errorf("Product(ints) = %d, want 24", got)
+	}
+	if got := Product([]int(nil)); got != 1 {
+		t.Errorf("Product(nil) = %d, want 1", got)
+	}
+	if got := Product([]float64{0.5, 0.5, 0.8}); got != 0.2 {
+		t.Errorf("Product(probabilities) = %v, want 0.2", got)
+	}
+	if got := Product([]int{3, 0, 5}); got != 0 {
+		t.Errorf("Product with a zero element = %d, want 0", got)
+	}
+	if got := Product([]float64{0, math.Inf(1)}); !math.IsNaN(got) {
+		t.Errorf("Product(0, +Inf) = %v, want NaN", got)
+	}
+}
+
+func TestMean(t *testing.T) {
+	if got := Mean([]int{1, 2, 3, 4}); got != 2.5 {
+		t.Errorf("Mean(ints) = %v, want 2.5", got)
+	}
+	if got := Mean([]float32{1, 2}); got != 1.5 {
+		t.Errorf("Mean(float32s) = %v, want 1.5", got)
+	}
+	if got := Mean([]int(nil)); !math.IsNaN(got) {
+		t.Errorf("Mean(nil) = %v, want NaN", got)
+	}
+
+	// The integer sum of these elements overflows int64, but the mean
+	// is representable.
+	large := []int64{math.MaxInt64, math.MaxInt64, math.MaxInt64 - 2, math.MaxInt64 - 4}
+	if got, want := Mean(large), float64(math.MaxInt64); got != want {
+		t.Errorf("Mean(%v) = %v, want %v", large, got, want)
+	}
+	if Sum(large) >= 0 {
+		t.Fatalf("test data no longer overflows the integer sum")
+	}
+}
+
+func TestMedian(t *testing.T) {
+	if got := Median([]int{3, 1, 2}); got != 2 {
+		t.Errorf("Median([3 1 2]) = %v, want 2", got)
+	}
+	if got := Median([]int{4, 1, 3, 2}); got != 2.5 {
+		t.Errorf("Median([4 1 3 2]) = %v, want 2.5", got)
+	}
+	if got := Median([]float64{7}); got != 7 {
+		t.Errorf("Median([7]) = %v, want 7", got)
+	}
+	if got := Median([]int(nil)); !math.IsNaN(got) {
+		t.Errorf("Median(nil) = %v, want NaN", got)
+	}
+	if got := Median([]float64{1, math.NaN(), 3}); !math.IsNaN(got) {
+		t.Errorf("Median with NaN = %v, want NaN", got)
+	}
+}
+
+func TestPercentile(t *testing.T) {
+	s := []int{50, 10, 40, 20, 30}
+	for _, tt := range []struct {
+		p, want float64
+	}{
+		{0, 10},
+		{25, 20},
+		{50, 30},
+		{90, 46},
+		{100, 50},
+	} {
+		if got := Percentile(s, tt.p); math.Abs(got-tt.want) > 1e-9 {
+			t.Errorf("Percentile(%v, %v) = %v, want %v", s, tt.p, got, tt.want)
+		}
+	}
+	if !Equal(s, []int{50, 10, 40, 20, 30}) {
+		t.Errorf("Percentile modified its input: %v", s)
+	}
+
+	r := rand.New(rand.NewSource(1))
+	for iter := 0; iter < 100; iter++ {
+		data := make([]float64, 1+r.Intn(50))
+		for i := range data {
+			data[i] = r.Float64()
+		}
+		sorted := append([]float64(nil), data...)
+		Sort(sorted)
+		p := r.Float64() * 100
+		rank := p / 100 * float64(len(sorted)-1)
+		lo := int(math.Floor(rank))
+		want := sorted[lo]
+		if lo+1 < len(sorted) {
+			want += (rank - float64(lo)) * (sorted[lo+1] - sorted[lo])
+		}
+		if got := Percentile(data, p); math.Abs(got-want) > 1e-12 {
+			t.Fatalf("Percentile(%v, %v) = %v, want %v", data, p, got, want)
+		}
+	}
+}
+
+func TestPercentilePanics(t *testing.T) {
+	for _, p := range []float64{-1, 100.5, math.NaN()} {
+		func() {
+			defer func() {
+				if recover() == nil {
+					t.Errorf("Percentile(s, %v) did not panic", p)
+				}
+			}()
+			Percentile([]int{1, 2}, p)
+		}()
+	}
+	defer func() {
+		if recover() == nil {
+			t.Errorf("Percentile(nil, 101) did not panic")
+		}
+	}()
+	Percentile([]int(nil), 101)
+}
+
+func TestAccumulate(t *testing.T) {
+	for _, tt := range []struct {
+		data []int
+		want []int
+	}{
+		{nil, []int{}},
+		{[]int{5}, []int{5}},
+		{[]int{3, 0, 2, 1}, []int{3, 3, 5, 6}},
+	} {
+		orig := Clone(tt.data)
+		if got := Accumulate(tt.data); !Equal(got, tt.want) {
+			t.Errorf("Accumulate(%v) = %v, want %v", tt.data, got, tt.want)
+		}
+		if !Equal(tt.data, orig) {
+			t.Errorf("Accumulate modified its input")
+		}
+		s := Clone(tt.data)
+		if got := AccumulateInPlace(s); !Equal(got, tt.want) || !Equal(s, tt.want) {
+			t.Errorf("AccumulateInPlace(%v) = %v, want %v", tt.data, got, tt.want)
+		}
+	}
+}
+
+func TestAccumulateRandom(t *testing.T) {
+	r := rand.New(rand.NewSource(1))
+	for iter := 0; iter < 100; iter++ {
+		s := make([]int, r.Intn(50))
+		for i := range s {
+			s[i] = r.Intn(1000) - 500
+		}
+		got := Accumulate(s)
+		for i := range s {
+			if want := Sum(s[:i+1]); got[i] != want {
+				t.Fatalf("Accumulate(%v)[%d] = %d, want %d", s, i, got[i], want)
+			}
+		}
+	}
+}
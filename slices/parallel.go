@@ -0,0 +1,177 @@
+package slices
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"runtime"
+	"strings"
+	"sync"
+	"sync/atomic"
+)
+
+// ParallelMap is like MapErr, but calls f concurrently from up to workers
+// goroutines; if workers is zero or negative, runtime.GOMAXPROCS(0) is
+// used. The i-th element of the result is always the value f returned for
+// s[i], whatever order the calls complete in.
+// The first call to fail cancels the context passed to the other calls,
+// no further calls are started, and its error is returned wrapped with the
+// index of the failing element. If ctx is done before every element has
+// been processed, ParallelMap returns ctx.Err(). In both cases the results
+// are discarded.
+func ParallelMap[T, U any](ctx context.Context, s []T, workers int, f func(context.Context, T) (U, error)) ([]U, error) {
+	if s == nil {
+		return nil, nil
+	}
+	s2 := make([]U, len(s))
+	err := parallelDo(ctx, len(s), workers, func(ctx context.Context, i int) error {
+		u, err := f(ctx, s[i])
+		if err != nil {
+			return fmt.Errorf("index %d: %w", i, err)
+		}
+		s2[i] = u
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return s2, nil
+}
+
+// ParallelForEach calls f for each element of s from up to workers
+// goroutines, with workers defaulting as in ParallelMap, and waits for the
+// calls to finish. A failing call does not stop the others: the errors of
+// all failing calls are returned together, each wrapped with the index of
+// its element and ordered by index. If ctx is done before every element
+// has been processed, no further calls are started and ctx.Err() is
+// included in the result.
+// The returned error supports errors.Is and errors.As for each of the
+// individual errors.
+func ParallelForEach[T any](ctx context.Context, s []T, workers int, f func(context.Context, T) error) error {
+	errs := make([]error, len(s)+1)
+	errs[len(s)] = parallelDo(ctx, len(s), workers, func(ctx context.Context, i int) error {
+		if err := f(ctx, s[i]); err != nil {
+			errs[i] = fmt.Errorf("index %d: %w", i, err)
+		}
+		return nil
+	})
+	return joinErrors(errs)
+}
+
+// joinError is an error wrapping several others, like the one returned by
+// errors.Join in Go 1.20 and later, which this module does not require.
+type joinError struct {
+	errs []error
+}
+
+func (e *joinError) Error() string {
+	msgs := make([]string, len(e.errs))
+	for i, err := range e.errs {
+		msgs[i] = err.Error()
+	}
+	return strings.Join(msgs, "\n")
+}
+
+// Unwrap returns the wrapped errors, for errors.Is and errors.As in Go 1.20
+// and later.
+func (e *joinError) Unwrap() []error {
+	return e.errs
+}
+
+// Is reports whether any of the wrapped errors matches target, so that
+// errors.Is works before Go 1.20 as well.
+func (e *joinError) Is(target error) bool {
+	for _, err := range e.errs {
+		if errors.Is(err, target) {
+			return true
+		}
+	}
+	return false
+}
+
+// As finds the first wrapped error that matches target, so that errors.As
+// works before Go 1.20 as well.
+func (e *joinError) As(target any) bool {
+	for _, err := range e.errs {
+		if errors.As(err, target) {
+			return true
+		}
+	}
+	return false
+}
+
+// joinErrors returns an error wrapping the non-nil errors of errs, the
+// single such error if there is only one, or nil if there are none.
+func joinErrors(errs []error) error {
+	var nonNil []error
+	for _, err := range errs {
+		if err != nil {
+			nonNil = append(nonNil, err)
+		}
+	}
+	switch len(nonNil) {
+	case 0:
+		return nil
+	case 1:
+		return nonNil[0]
+	}
+	return &joinError{nonNil}
+}
+
+// parallelDo calls f for each index in [0, n) from up to workers
+// goroutines and waits for the calls to finish. Each goroutine takes the
+// next unprocessed index until none are left or the derived context passed
+// to f is done. The first error returned by f cancels that context and is
+// returned; otherwise, ctx.Err() is returned if any index was skipped.
+func parallelDo(ctx context.Context, n, workers int, f func(context.Context, int) error) error {
+	if workers <= 0 {
+		workers = runtime.GOMAXPROCS(0)
+	}
+	if workers > n {
+		workers = n
+	}
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	var (
+		next     int64 = -1
+		skipped  int32
+		mu       sync.Mutex
+		firstErr error
+		wg       sync.WaitGroup
+	)
+	wg.Add(workers)
+	for w := 0; w < workers; w++ {
+		go func() {
+			defer wg.Done()
+			for {
+				i := int(atomic.AddInt64(&next, 1))
+				if i >= n {
+					return
+				}
+				if ctx.Err() != nil {
+					atomic.StoreInt32(&skipped, 1)
+					return
+				}
+				if err := f(ctx, i); err != nil {
+					mu.Lock()
+					if firstErr == nil {
+						firstErr = err
+						cancel()
+					}
+					mu.Unlock()
+					return
+				}
+			}
+		}()
+	}
+	wg.Wait()
+
+	if firstErr != nil {
+		return firstErr
+	}
+	if atomic.LoadInt32(&skipped) != 0 {
+		return ctx.Err()
+	}
+	return nil
+}
@@ -0,0 +1,198 @@
+package slices
+
+import (
+	"context"
+	"crypto/sha256"
+	"errors"
+	"strconv"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestParallelMap(t *testing.T) {
+	s := make([]int, 100)
+	for i := range s {
+		s[i] = i
+	}
+	for _, workers := range []int{-1, 0, 1, 4, 1000} {
+		got, err := ParallelMap(context.Background(), s, workers, func(ctx context.Context, v int) (string, error) {
+			// Finish later elements first to scramble completion order.
+			time.Sleep(time.Duration(len(s)-v) * time.Microsecond)
+			return strconv.Itoa(v), nil
+		})
+		if err != nil {
+			t.Fatalf("ParallelMap(workers=%d) returned error: %v", workers, err)
+		}
+		if want := Map(s, strconv.Itoa); !Equal(got, want) {
+			t.Errorf("ParallelMap(workers=%d) = %v, want %v", workers, got, want)
+		}
+	}
+
+	got, err := ParallelMap(context.Background(), []int(nil), 2, func(ctx context.Context, v int) (int, error) { return v, nil })
+	if got != nil || err != nil {
+		t.Errorf("ParallelMap(nil) = %v, %v, want nil, nil", got, err)
+	}
+}
+
+func TestParallelMapError(t *testing.T) {
+	errBad := errors.New("bad element")
+	s := make([]int, 50)
+	for i := range s {
+		s[i] = i
+	}
+	got, err := ParallelMap(context.Background(), s, 4, func(ctx context.Context, v int) (int, error) {
+		if v == 3 {
+			return 0, errBad
+		}
+		// Block until the failure above cancels the context.
+		select {
+		case <-ctx.Done():
+			return 0, ctx.Err()
+		case <-time.After(10 * time.Second):
+			return v, nil
+		}
+	})
+	if !errors.Is(err, errBad) {
+		t.Fatalf("ParallelMap error = %v, want %v", err, errBad)
+	}
+	if want := "index 3: bad element"; err.Error() != want {
+		t.Errorf("ParallelMap error = %q, want %q", err, want)
+	}
+	if got != nil {
+		t.Errorf("ParallelMap returned results %v along with an error", got)
+	}
+}
+
+func TestParallelMapCanceled(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	calls := 0
+	_, err := ParallelMap(ctx, []int{1, 2, 3}, 1, func(ctx context.Context, v int) (int, error) {
+		calls++
+		return v, nil
+	})
+	if !errors.Is(err, context.Canceled) {
+		t.Errorf("ParallelMap with canceled context returned %v, want %v", err, context.Canceled)
+	}
+	if calls != 0 {
+		t.Errorf("ParallelMap with canceled context called f %d times", calls)
+	}
+}
+
+func benchmarkParallelInput() [][]byte {
+	s := make([][]byte, 256)
+	for i := range s {
+		s[i] = make([]byte, 64<<10)
+		s[i][0] = byte(i)
+	}
+	return s
+}
+
+func hash(b []byte) ([32]byte, error) {
+	return sha256.Sum256(b), nil
+}
+
+func BenchmarkParallelMap(b *testing.B) {
+	s := benchmarkParallelInput()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		ParallelMap(context.Background(), s, 0, func(ctx context.Context, v []byte) ([32]byte, error) {
+			return hash(v)
+		})
+	}
+}
+
+func BenchmarkParallelMapSequentialMapErr(b *testing.B) {
+	s := benchmarkParallelInput()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		MapErr(s, hash)
+	}
+}
+
+func TestParallelForEachBound(t *testing.T) {
+	for _, workers := range []int{1, 3, 8} {
+		var running, maxRunning, calls int64
+		s := make([]int, 40)
+		err := ParallelForEach(context.Background(), s, workers, func(ctx context.Context, v int) error {
+			n := atomic.AddInt64(&running, 1)
+			for {
+				m := atomic.LoadInt64(&maxRunning)
+				if n <= m || atomic.CompareAndSwapInt64(&maxRunning, m, n) {
+					break
+				}
+			}
+			time.Sleep(200 * time.Microsecond)
+			atomic.AddInt64(&running, -1)
+			atomic.AddInt64(&calls, 1)
+			return nil
+		})
+		if err != nil {
+			t.Errorf("ParallelForEach(workers=%d) returned error: %v", workers, err)
+		}
+		if calls != int64(len(s)) {
+			t.Errorf("ParallelForEach(workers=%d) made %d calls, want %d", workers, calls, len(s))
+		}
+		if maxRunning > int64(workers) {
+			t.Errorf("ParallelForEach(workers=%d) ran %d calls at once", workers, maxRunning)
+		}
+	}
+}
+
+func TestParallelForEachErrors(t *testing.T) {
+	errOdd := errors.New("odd")
+	var numErr *strconv.NumError
+	var calls int64
+	err := ParallelForEach(context.Background(), []string{"1", "2", "x", "4", "5"}, 2, func(ctx context.Context, v string) error {
+		atomic.AddInt64(&calls, 1)
+		n, err := strconv.Atoi(v)
+		if err != nil {
+			return err
+		}
+		if n%2 == 1 {
+			return errOdd
+		}
+		return nil
+	})
+	if calls != 5 {
+		t.Errorf("ParallelForEach stopped after %d calls, want all 5", calls)
+	}
+	if !errors.Is(err, errOdd) {
+		t.Errorf("errors.Is(%v, errOdd) = false", err)
+	}
+	if !errors.As(err, &numErr) || numErr.Num != "x" {
+		t.Errorf("errors.As(%v, *strconv.NumError) failed", err)
+	}
+	want := "index 0: odd\nindex 2: " + `strconv.Atoi: parsing "x": invalid syntax` + "\nindex 4: odd"
+	if err == nil || err.Error() != want {
+		t.Errorf("ParallelForEach error = %q, want %q", err, want)
+	}
+
+	err = ParallelForEach(context.Background(), []int{1, 2}, 2, func(ctx context.Context, v int) error {
+		if v == 2 {
+			return errOdd
+		}
+		return nil
+	})
+	if err == nil || err.Error() != "index 1: odd" {
+		t.Errorf("ParallelForEach with one failure = %v", err)
+	}
+}
+
+func TestParallelForEachCanceled(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	var calls int64
+	err := ParallelForEach(ctx, make([]int, 100), 1, func(ctx context.Context, v int) error {
+		if atomic.AddInt64(&calls, 1) == 3 {
+			cancel()
+		}
+		return nil
+	})
+	if !errors.Is(err, context.Canceled) {
+		t.Errorf("ParallelForEach after cancel returned %v, want %v", err, context.Canceled)
+	}
+	if calls != 3 {
+		t.Errorf("ParallelForEach made %d calls after cancel, want 3", calls)
+	}
+}
@@ -0,0 +1,120 @@
+package slices
+
+import (
+	"math/rand"
+
+	"github.com/syumai/go-generics/constraints"
+)
+
+// Shuffle pseudo-randomizes the order of the elements of s in place using
+// the Fisher–Yates algorithm and the default Source of math/rand.
+func Shuffle[S constraints.Slice[T], T any](s S) {
+	shuffle(s, rand.Intn)
+}
+
+// ShuffleRand is like Shuffle, but draws random numbers from r, which
+// makes the resulting permutation reproducible for a given seed.
+func ShuffleRand[S constraints.Slice[T], T any](s S, r *rand.Rand) {
+	shuffle(s, r.Intn)
+}
+
+// Sample returns an element of s chosen uniformly at random using the
+// default Source of math/rand, and true. If s is empty, Sample returns the
+// zero value of T and false.
+func Sample[T any](s []T) (T, bool) {
+	return sample(s, rand.Intn)
+}
+
+// SampleRand is like Sample, but draws random numbers from r.
+func SampleRand[T any](s []T, r *rand.Rand) (T, bool) {
+	return sample(s, r.Intn)
+}
+
+// SampleN returns n distinct elements of s chosen uniformly at random using
+// the default Source of math/rand, in random order. s is not modified.
+// If n >= len(s), SampleN returns a shuffled copy of all of s.
+// SampleN panics if n is negative.
+func SampleN[S constraints.Slice[T], T any](s S, n int) S {
+	return sampleN(s, n, rand.Intn)
+}
+
+// SampleNRand is like SampleN, but draws random numbers from r.
+func SampleNRand[S constraints.Slice[T], T any](s S, n int, r *rand.Rand) S {
+	return sampleN(s, n, r.Intn)
+}
+
+func sample[T any](s []T, intn func(n int) int) (T, bool) {
+	if len(s) == 0 {
+		var zero T
+		return zero, false
+	}
+	return s[intn(len(s))], true
+}
+
+// sampleN runs the first n steps of a Fisher–Yates shuffle on a copy of s.
+func sampleN[S constraints.Slice[T], T any](s S, n int, intn func(n int) int) S {
+	if n < 0 {
+		panic("slices.SampleN: negative count")
+	}
+	s2 := Clone(s)
+	if n >= len(s2) {
+		shuffle(s2, intn)
+		return s2
+	}
+	for i := 0; i < n; i++ {
+		j := i + intn(len(s2)-i)
+		s2[i], s2[j] = s2[j], s2[i]
+	}
+	return s2[:n:n]
+}
+
+// WeightedSample returns an element of s chosen at random using r, where
+// the element at index i is chosen with probability weights[i]/sum(weights),
+// and true. Elements with a zero weight are never chosen.
+// WeightedSample returns the zero value of T and false if s is empty or all
+// weights are zero. It panics if len(weights) != len(s) or if any weight is
+// negative or NaN.
+func WeightedSample[T any](s []T, weights []float64, r *rand.Rand) (T, bool) {
+	if len(weights) != len(s) {
+		panic("slices.WeightedSample: len(weights) != len(s)")
+	}
+	var zero T
+	if len(s) == 0 {
+		return zero, false
+	}
+	// cum[i] is the sum of weights[0] through weights[i].
+	cum := make([]float64, len(weights))
+	total := 0.0
+	for i, w := range weights {
+		if !(w >= 0) {
+			panic("slices.WeightedSample: negative or NaN weight")
+		}
+		total += w
+		cum[i] = total
+	}
+	if total == 0 {
+		return zero, false
+	}
+	x := r.Float64() * total
+	// Find the first index whose cumulative weight exceeds x.
+	i, _ := BinarySearchFunc(cum, x, func(c, x float64) int {
+		if c <= x {
+			return -1
+		}
+		return 1
+	})
+	if i == len(s) {
+		// Guard against rounding in the product above.
+		i = LastIndexFunc(weights, func(w float64) bool { return w > 0 })
+	}
+	return s[i], true
+}
+
+// shuffle performs a Fisher–Yates shuffle of s; intn(n) must return a
+// uniformly distributed integer in [0, n).
+func shuffle[T any](s []T, intn func(n int) int) {
+	for i := len(s) - 1; i > 0; i-- {
+		j := intn(i + 1)
+		s[i], s[j] = s[j], s[i]
+	}
+}
@@ -0,0 +1,37 @@
+//go:build long
+
+package slices
+
+import (
+	"math/rand"
+	"testing"
+)
+
+// TestShuffleUniformity is a crude check that every element ends up in every
+// position about equally often. Run it with "go test -tags long".
+func TestShuffleUniformity(t *testing.T) {
+	const (
+		n      = 8
+		trials = 800000
+	)
+	r := rand.New(rand.NewSource(1))
+	var counts [n][n]int // counts[value][position]
+	s := make([]int, n)
+	for trial := 0; trial < trials; trial++ {
+		for i := range s {
+			s[i] = i
+		}
+		ShuffleRand(s, r)
+		for pos, v := range s {
+			counts[v][pos]++
+		}
+	}
+	const want = trials / n
+	for v := range counts {
+		for pos, c := range counts[v] {
+			if c < want*95/100 || c > want*105/100 {
+				t.Errorf("value %d landed in position %d %d times, want about %d", v, pos, c, want)
+			}
+		}
+	}
+}
@@ -0,0 +1,161 @@
+package slices
+
+import (
+	"math"
+	"math/rand"
+	"testing"
+)
+
+func TestShuffleRand(t *testing.T) {
+	s := []int{0, 1, 2, 3, 4, 5, 6, 7, 8, 9}
+	ShuffleRand(s, rand.New(rand.NewSource(42)))
+	if want := []int{3, 7, 2, 9, 0, 6, 1, 4, 8, 5}; !Equal(s, want) {
+		t.Errorf("ShuffleRand with seed 42 = %v, want %v", s, want)
+	}
+
+	// The same seed must produce the same permutation.
+	s2 := []int{0, 1, 2, 3, 4, 5, 6, 7, 8, 9}
+	ShuffleRand(s2, rand.New(rand.NewSource(42)))
+	if !Equal(s, s2) {
+		t.Errorf("ShuffleRand is not deterministic: %v != %v", s, s2)
+	}
+
+	ShuffleRand([]int(nil), rand.New(rand.NewSource(1)))
+	ShuffleRand([]int{1}, rand.New(rand.NewSource(1)))
+}
+
+func TestShuffle(t *testing.T) {
+	type IDs []int
+	s := IDs{0, 1, 2, 3, 4, 5, 6, 7, 8, 9}
+	Shuffle(s)
+	sorted := Clone(s)
+	Sort(sorted)
+	if want := (IDs{0, 1, 2, 3, 4, 5, 6, 7, 8, 9}); !Equal(sorted, want) {
+		t.Errorf("Shuffle did not produce a permutation: %v", s)
+	}
+}
+
+func TestSampleRand(t *testing.T) {
+	r := rand.New(rand.NewSource(1))
+	if v, ok := SampleRand([]int(nil), r); ok || v != 0 {
+		t.Errorf("SampleRand(nil) = %d, %t, want 0, false", v, ok)
+	}
+	if v, ok := SampleRand([]string{"only"}, r); !ok || v != "only" {
+		t.Errorf("SampleRand([only]) = %q, %t, want only, true", v, ok)
+	}
+
+	s := []int{10, 20, 30}
+	seen := make(map[int]bool)
+	for i := 0; i < 100; i++ {
+		v, ok := SampleRand(s, r)
+		if !ok || !Contains(s, v) {
+			t.Fatalf("SampleRand(%v) = %d, %t", s, v, ok)
+		}
+		seen[v] = true
+	}
+	if len(seen) != len(s) {
+		t.Errorf("SampleRand never returned some elements of %v in 100 draws: %v", s, seen)
+	}
+
+	if _, ok := Sample(s); !ok {
+		t.Errorf("Sample(%v) returned false", s)
+	}
+}
+
+func TestSampleNRand(t *testing.T) {
+	type IDs []int
+	s := IDs{0, 1, 2, 3, 4, 5, 6, 7, 8, 9}
+	orig := Clone(s)
+	r := rand.New(rand.NewSource(1))
+	for _, n := range []int{0, 1, 5, 10, 20} {
+		got := SampleNRand(s, n, r)
+		wantLen := n
+		if n > len(s) {
+			wantLen = len(s)
+		}
+		if len(got) != wantLen {
+			t.Errorf("SampleNRand(_, %d) returned %d elements, want %d", n, len(got), wantLen)
+		}
+		if len(Unique(got)) != len(got) {
+			t.Errorf("SampleNRand(_, %d) = %v contains duplicates", n, got)
+		}
+		if !ContainsAll(s, got...) {
+			t.Errorf("SampleNRand(_, %d) = %v contains foreign elements", n, got)
+		}
+	}
+	if !Equal(s, orig) {
+		t.Errorf("SampleNRand modified its input: %v", s)
+	}
+
+	a := SampleNRand(s, 3, rand.New(rand.NewSource(7)))
+	b := SampleNRand(s, 3, rand.New(rand.NewSource(7)))
+	if !Equal(a, b) {
+		t.Errorf("SampleNRand is not deterministic: %v != %v", a, b)
+	}
+	if got := SampleN(s, 3); len(got) != 3 {
+		t.Errorf("SampleN(_, 3) = %v", got)
+	}
+}
+
+func TestSampleNPanics(t *testing.T) {
+	defer func() {
+		if recover() == nil {
+			t.Errorf("SampleN(_, -1) did not panic")
+		}
+	}()
+	SampleN([]int{1}, -1)
+}
+
+func TestWeightedSample(t *testing.T) {
+	r := rand.New(rand.NewSource(1))
+	s := []string{"a", "b", "c", "d"}
+	weights := []float64{1, 0, 3, 6}
+	const trials = 100000
+	counts := make(map[string]int)
+	for i := 0; i < trials; i++ {
+		v, ok := WeightedSample(s, weights, r)
+		if !ok {
+			t.Fatalf("WeightedSample returned false")
+		}
+		counts[v]++
+	}
+	if counts["b"] != 0 {
+		t.Errorf("element with zero weight chosen %d times", counts["b"])
+	}
+	for i, v := range s {
+		want := weights[i] / 10
+		got := float64(counts[v]) / trials
+		if math.Abs(got-want) > 0.01 {
+			t.Errorf("frequency of %q = %.4f, want %.4f ± 0.01", v, got, want)
+		}
+	}
+
+	if v, ok := WeightedSample([]string(nil), nil, r); ok || v != "" {
+		t.Errorf("WeightedSample(nil) = %q, %t, want \"\", false", v, ok)
+	}
+	if _, ok := WeightedSample(s, []float64{0, 0, 0, 0}, r); ok {
+		t.Errorf("WeightedSample with all-zero weights returned true")
+	}
+}
+
+func TestWeightedSamplePanics(t *testing.T) {
+	r := rand.New(rand.NewSource(1))
+	for _, tt := range []struct {
+		name    string
+		s       []int
+		weights []float64
+	}{
+		{"length mismatch", []int{1, 2}, []float64{1}},
+		{"negative weight", []int{1, 2}, []float64{1, -1}},
+		{"NaN weight", []int{1, 2}, []float64{1, math.NaN()}},
+	} {
+		func() {
+			defer func() {
+				if recover() == nil {
+					t.Errorf("%s: WeightedSample did not panic", tt.name)
+				}
+			}()
+			WeightedSample(tt.s, tt.weights, r)
+		}()
+	}
+}
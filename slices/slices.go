@@ -5,7 +5,12 @@
 //
 package slices
 
-import "github.com/syumai/go-generics/constraints" // See https://github.com/golang/go/issues/45458
+import (
+	"fmt"
+	"math"
+
+	"github.com/syumai/go-generics/constraints" // See https://github.com/golang/go/issues/45458
+)
 
 // Equal reports whether two slices are equal: the same length and all
 // elements equal. If the lengths are different, Equal returns false.
@@ -41,6 +46,88 @@ func EqualFunc[T1, T2 any](s1 []T1, s2 []T2, eq func(T1, T2) bool) bool {
 	return true
 }
 
+// HasPrefix reports whether s begins with prefix.
+// An empty prefix is a prefix of every slice.
+func HasPrefix[T comparable](s, prefix []T) bool {
+	return len(s) >= len(prefix) && Equal(s[:len(prefix)], prefix)
+}
+
+// HasPrefixFunc is like HasPrefix, but uses eq to compare elements.
+func HasPrefixFunc[T1, T2 any](s []T1, prefix []T2, eq func(T1, T2) bool) bool {
+	return len(s) >= len(prefix) && EqualFunc(s[:len(prefix)], prefix, eq)
+}
+
+// HasSuffix reports whether s ends with suffix.
+// An empty suffix is a suffix of every slice.
+func HasSuffix[T comparable](s, suffix []T) bool {
+	return len(s) >= len(suffix) && Equal(s[len(s)-len(suffix):], suffix)
+}
+
+// HasSuffixFunc is like HasSuffix, but uses eq to compare elements.
+func HasSuffixFunc[T1, T2 any](s []T1, suffix []T2, eq func(T1, T2) bool) bool {
+	return len(s) >= len(suffix) && EqualFunc(s[len(s)-len(suffix):], suffix, eq)
+}
+
+// EqualUnordered reports whether s1 and s2 hold the same elements with the
+// same multiplicities, in any order. If the lengths are different,
+// EqualUnordered returns false without examining the elements.
+// It runs in O(len(s1)) time using a counting map.
+func EqualUnordered[T comparable](s1, s2 []T) bool {
+	return EqualUnorderedFunc(s1, s2, func(v T) T { return v })
+}
+
+// EqualUnorderedFunc is like EqualUnordered, but compares the keys returned
+// by key instead of the elements themselves, so T need not be comparable.
+func EqualUnorderedFunc[T any, K comparable](s1, s2 []T, key func(T) K) bool {
+	if len(s1) != len(s2) {
+		return false
+	}
+	counts := make(map[K]int, len(s1))
+	for _, v := range s1 {
+		counts[key(v)]++
+	}
+	for _, v := range s2 {
+		k := key(v)
+		n, ok := counts[k]
+		if !ok {
+			return false
+		}
+		if n == 1 {
+			delete(counts, k)
+		} else {
+			counts[k] = n - 1
+		}
+	}
+	return len(counts) == 0
+}
+
+// Mismatch returns the index of the first element at which s1 and s2
+// differ, or -1 if they are equal. If one slice is a prefix of the other,
+// the result is the length of the shorter one, the first index at which
+// only one slice has an element. Floating point NaNs are not considered
+// equal.
+func Mismatch[T comparable](s1, s2 []T) int {
+	return MismatchFunc(s1, s2, func(v1, v2 T) bool { return v1 == v2 })
+}
+
+// MismatchFunc is like Mismatch but uses eq to compare each pair of
+// elements.
+func MismatchFunc[T1, T2 any](s1 []T1, s2 []T2, eq func(T1, T2) bool) int {
+	n := len(s1)
+	if n > len(s2) {
+		n = len(s2)
+	}
+	for i := 0; i < n; i++ {
+		if !eq(s1[i], s2[i]) {
+			return i
+		}
+	}
+	if len(s1) == len(s2) {
+		return -1
+	}
+	return n
+}
+
 // Compare compares the elements of s1 and s2.
 // The elements are compared sequentially starting at index 0,
 // until one element is not equal to the other. The result of comparing
@@ -123,6 +210,160 @@ func IndexFunc[T any](s []T, f func(T) bool) int {
 	return -1
 }
 
+// IndexFrom returns the index of the first occurrence of v in s at or after
+// index start, or -1 if not present. The returned index is relative to the
+// start of s, not to start. A negative start is treated as 0, and a start
+// at or beyond len(s) returns -1.
+func IndexFrom[T comparable](s []T, v T, start int) int {
+	if start < 0 {
+		start = 0
+	}
+	for i := start; i < len(s); i++ {
+		if s[i] == v {
+			return i
+		}
+	}
+	return -1
+}
+
+// IndexFuncFrom is like IndexFrom, but returns the index of the first
+// element at or after start satisfying f(c).
+func IndexFuncFrom[T any](s []T, f func(T) bool, start int) int {
+	if start < 0 {
+		start = 0
+	}
+	for i := start; i < len(s); i++ {
+		if f(s[i]) {
+			return i
+		}
+	}
+	return -1
+}
+
+// IndexAll returns the indices of all occurrences of v in s in ascending
+// order, or nil if v is not present.
+func IndexAll[T comparable](s []T, v T) []int {
+	var idx []int
+	for i := 0; i < len(s); i++ {
+		if s[i] == v {
+			idx = append(idx, i)
+		}
+	}
+	return idx
+}
+
+// IndexAllFunc returns the indices of all elements of s satisfying f(c)
+// in ascending order, or nil if none do.
+func IndexAllFunc[T any](s []T, f func(T) bool) []int {
+	var idx []int
+	for i := 0; i < len(s); i++ {
+		if f(s[i]) {
+			idx = append(idx, i)
+		}
+	}
+	return idx
+}
+
+// IndexSubslice returns the index of the first instance of sub as a
+// contiguous run of elements in s, or -1 if sub is not present in s.
+// An empty sub is found at index 0.
+func IndexSubslice[T comparable](s, sub []T) int {
+	n := len(sub)
+	for i := 0; i+n <= len(s); i++ {
+		if Equal(s[i:i+n], sub) {
+			return i
+		}
+	}
+	return -1
+}
+
+// LastIndex returns the index of the last occurrence of v in s, or -1 if not present.
+func LastIndex[T comparable](s []T, v T) int {
+	for i := len(s) - 1; i >= 0; i-- {
+		if s[i] == v {
+			return i
+		}
+	}
+	return -1
+}
+
+// LastIndexFunc returns the index into s of the last element
+// satisfying f(c), or -1 if none do.
+func LastIndexFunc[T any](s []T, f func(T) bool) int {
+	for i := len(s) - 1; i >= 0; i-- {
+		if f(s[i]) {
+			return i
+		}
+	}
+	return -1
+}
+
+// Find returns the first element of s satisfying f, and true.
+// If no element satisfies f, Find returns the zero value of T and false.
+func Find[T any](s []T, f func(T) bool) (T, bool) {
+	if i := IndexFunc(s, f); i >= 0 {
+		return s[i], true
+	}
+	var zero T
+	return zero, false
+}
+
+// FindLast is like Find, but returns the last element of s satisfying f.
+func FindLast[T any](s []T, f func(T) bool) (T, bool) {
+	if i := LastIndexFunc(s, f); i >= 0 {
+		return s[i], true
+	}
+	var zero T
+	return zero, false
+}
+
+// Get returns s[i] and true if i is a valid index of s, and the zero value
+// of T and false otherwise, instead of panicking.
+func Get[T any](s []T, i int) (T, bool) {
+	if i < 0 || i >= len(s) {
+		var zero T
+		return zero, false
+	}
+	return s[i], true
+}
+
+// At is like Get, but a negative i counts back from the end of s: -1 is
+// the last element and -len(s) the first.
+func At[T any](s []T, i int) (T, bool) {
+	if i < 0 {
+		i += len(s)
+	}
+	return Get(s, i)
+}
+
+// First returns the first element of s and true, or the zero value of T
+// and false if s is empty.
+func First[T any](s []T) (T, bool) {
+	return Get(s, 0)
+}
+
+// Last returns the last element of s and true, or the zero value of T and
+// false if s is empty.
+func Last[T any](s []T) (T, bool) {
+	return Get(s, len(s)-1)
+}
+
+// FirstOr returns the first element of s, or fallback if s is empty.
+func FirstOr[T any](s []T, fallback T) T {
+	if len(s) == 0 {
+		return fallback
+	}
+	return s[0]
+}
+
+// LastOr returns the last element of s, or fallback if s is empty.
+func LastOr[T any](s []T, fallback T) T {
+	if len(s) == 0 {
+		return fallback
+	}
+	return s[len(s)-1]
+}
+
 // Contains reports whether v is present in s.
 func Contains[T comparable](s []T, v T) bool {
 	for i := 0; i < len(s); i++ {
@@ -133,6 +374,117 @@ func Contains[T comparable](s []T, v T) bool {
 	return false
 }
 
+// ContainsSubsequence reports whether the elements of sub appear in s in
+// the same order, though not necessarily next to each other.
+// Unlike IndexSubslice, other elements may occur between them.
+// An empty sub is a subsequence of every slice.
+func ContainsSubsequence[T comparable](s, sub []T) bool {
+	j := 0
+	for i := 0; i < len(s) && j < len(sub); i++ {
+		if s[i] == sub[j] {
+			j++
+		}
+	}
+	return j == len(sub)
+}
+
+// ContainsFunc reports whether at least one element e of s satisfies f(e).
+// It stops at the first element for which f returns true.
+func ContainsFunc[T any](s []T, f func(T) bool) bool {
+	return IndexFunc(s, f) >= 0
+}
+
+// ContainsAll reports whether every one of vs is present in s.
+// If vs is empty, ContainsAll returns true.
+// For large inputs a set is built from the smaller of s and vs, so the cost
+// is O(len(s)+len(vs)) rather than O(len(s)*len(vs)).
+func ContainsAll[T comparable](s []T, vs ...T) bool {
+	if !useSet(len(s), len(vs)) {
+		for _, v := range vs {
+			if !Contains(s, v) {
+				return false
+			}
+		}
+		return true
+	}
+	if len(s) <= len(vs) {
+		set := ToSet(s)
+		for _, v := range vs {
+			if _, ok := set[v]; !ok {
+				return false
+			}
+		}
+		return true
+	}
+	want := ToSet(vs)
+	for _, v := range s {
+		delete(want, v)
+		if len(want) == 0 {
+			return true
+		}
+	}
+	return false
+}
+
+// ContainsAny reports whether at least one of vs is present in s.
+// If vs is empty, ContainsAny returns false.
+// For large inputs a set is built from the smaller of s and vs, so the cost
+// is O(len(s)+len(vs)) rather than O(len(s)*len(vs)).
+func ContainsAny[T comparable](s []T, vs ...T) bool {
+	if !useSet(len(s), len(vs)) {
+		for _, v := range vs {
+			if Contains(s, v) {
+				return true
+			}
+		}
+		return false
+	}
+	small, large := s, vs
+	if len(small) > len(large) {
+		small, large = large, small
+	}
+	set := ToSet(small)
+	for _, v := range large {
+		if _, ok := set[v]; ok {
+			return true
+		}
+	}
+	return false
+}
+
+// setThreshold is the product of two input lengths above which membership
+// tests across both inputs build a map instead of scanning linearly.
+// It is the approximate crossover point measured by BenchmarkContainsAll.
+const setThreshold = 4096
+
+// useSet reports whether n*m exceeds setThreshold.
+func useSet(n, m int) bool {
+	return n != 0 && m > setThreshold/n
+}
+
+// Count returns the number of elements of s equal to v.
+func Count[T comparable](s []T, v T) int {
+	n := 0
+	for _, e := range s {
+		if e == v {
+			n++
+		}
+	}
+	return n
+}
+
+// CountFunc returns the number of elements e of s satisfying f(e).
+// f is called exactly once for each element, in order.
+func CountFunc[T any](s []T, f func(T) bool) int {
+	n := 0
+	for _, e := range s {
+		if f(e) {
+			n++
+		}
+	}
+	return n
+}
+
 // Insert inserts the values v... into s at index i, returning the modified slice.
 // In the returned slice r, r[i] == the first v.  Insert panics if i is out of range.
 //
@@ -148,26 +500,94 @@ func Contains[T comparable](s []T, v T) bool {
 // Future compiler optimizations might implement
 // both in the most efficient ways.
 func Insert[S constraints.Slice[T], T any](s S, i int, v ...T) S {
-	if n := len(s) + len(vs); n <= cap(s) {
+	_ = s[i:] // bounds check
+
+	if n := len(s) + len(v); n <= cap(s) {
 		s2 := s[:n]
-		copy(s2[i+len(vs):], s[i:])
-		copy(s2[i:], vs)
+		copy(s2[i+len(v):], s[i:])
+		copy(s2[i:], v)
 		return s2
 	}
-	s2 := make([]int, len(s) + len(vs))
+	s2 := make(S, len(s)+len(v))
 	copy(s2, s[:i])
-	copy(s2[i:], vs)
-	copy(s2[i+len(vs):], s[i:])
+	copy(s2[i:], v)
+	copy(s2[i+len(v):], s[i:])
 	return s2
 }
 
 // Delete removes the elements s[i:j] from s, returning the modified slice.
 // Delete panics if s[i:j] is not a valid slice of s.
 // Delete modifies the contents of the slice s; it does not create a new slice.
-// Delete is O(len(s)-(j-i)), so if many items must be deleted, it is better to
+// The j-i elements between the new length and the original length of s are
+// set to the zero value, so that values they referenced can be garbage
+// collected; they no longer hold copies of the shifted elements.
+// Delete is O(len(s)-i), so if many items must be deleted, it is better to
 // make a single call deleting them all together than to delete one at a time.
 func Delete[S constraints.Slice[T], T any](s S, i, j int) S {
-	return s[:i+copy(s[i:], s[j:])]
+	_ = s[i:j:len(s)] // bounds check
+
+	if i == j {
+		return s
+	}
+	n := i + copy(s[i:], s[j:])
+	clearSlice(s[n:])
+	return s[:n]
+}
+
+// DeleteFunc removes any elements from s for which pred returns true,
+// returning the modified slice. Like Compact, it shifts the remaining
+// elements to the front of s rather than creating a new slice.
+// The elements between the new length and the original length are set to
+// the zero value so that pointer elements are not kept alive.
+func DeleteFunc[S constraints.Slice[T], T any](s S, pred func(T) bool) S {
+	j := 0
+	for i, v := range s {
+		if pred(v) {
+			continue
+		}
+		if i != j {
+			s[j] = v
+		}
+		j++
+	}
+	clearSlice(s[j:])
+	return s[:j]
+}
+
+// Replace replaces the elements s[i:j] by the given v, and returns the
+// modified slice. It is equivalent to Delete(s, i, j) followed by
+// Insert(..., i, v...), but moves the tail s[j:] only once.
+// Replace panics if s[i:j] is not a valid slice of s.
+// When len(v) is greater than j-i, Replace reuses the capacity of s if
+// possible and otherwise allocates once. When len(v) is less than j-i, the
+// elements between the new length and the original length of s are zeroed,
+// as in Delete.
+func Replace[S constraints.Slice[T], T any](s S, i, j int, v ...T) S {
+	_ = s[i:j:len(s)] // bounds check
+
+	tot := len(s) - (j - i) + len(v)
+	if tot > cap(s) {
+		s2 := make(S, tot)
+		copy(s2, s[:i])
+		copy(s2[i:], v)
+		copy(s2[i+len(v):], s[j:])
+		return s2
+	}
+
+	r := s[:tot]
+	if i+len(v) <= j {
+		// The replacement is not longer than the removed range:
+		// write v first, then shift the tail left.
+		copy(r[i:], v)
+		copy(r[i+len(v):], s[j:])
+		clearSlice(s[tot:])
+		return r
+	}
+	// The replacement is longer: shift the tail right within the
+	// capacity first, then write v into the gap.
+	copy(r[i+len(v):], s[j:])
+	copy(r[i:], v)
+	return r
 }
 
 // Clone returns a copy of the slice.
@@ -178,9 +598,29 @@ func Clone[S constraints.Slice[T], T any](s S) S {
 	return s2
 }
 
+// CloneFunc returns a copy of the slice in which each element is
+// clone(v) of the corresponding element v of s, so that the caller decides
+// how deep the copy goes. Like Clone, it preserves the capacity of s.
+func CloneFunc[S constraints.Slice[T], T any](s S, clone func(T) T) S {
+	s2 := make(S, len(s), cap(s))
+	for i, v := range s {
+		s2[i] = clone(v)
+	}
+	return s2
+}
+
+// CloneDeep2D returns a copy of ss in which every inner slice is also
+// copied, so the result shares no backing arrays with ss. The elements of
+// the inner slices are still copied using assignment.
+func CloneDeep2D[T any](ss [][]T) [][]T {
+	return CloneFunc(ss, Clone[[]T])
+}
+
 // Compact replaces consecutive runs of equal elements with a single copy.
 // This is like the uniq command found on Unix.
 // Compact modifies the contents of the slice s; it does not create a new slice.
+// The elements between the new length and the original length of s are set
+// to the zero value, so that values they referenced can be garbage collected.
 func Compact[S constraints.Slice[T], T comparable](s S) S {
 	if len(s) == 0 || len(s) == 1 {
 		return s
@@ -193,10 +633,12 @@ func Compact[S constraints.Slice[T], T comparable](s S) S {
 		s[j] = s[i]
 		j++
 	}
+	clearSlice(s[j:])
 	return s[:j]
 }
 
 // CompactFunc is like Compact, but uses a comparison function.
+// As with Compact, the abandoned tail of s is zeroed.
 func CompactFunc[S constraints.Slice[T], T any](s S, cmp func(T, T) bool) S {
 	if len(s) == 0 || len(s) == 1 {
 		return s
@@ -209,18 +651,1267 @@ func CompactFunc[S constraints.Slice[T], T any](s S, cmp func(T, T) bool) S {
 		s[j] = s[i]
 		j++
 	}
+	clearSlice(s[j:])
 	return s[:j]
 }
 
+// Run is a maximal run of Count consecutive elements equal to Value, as
+// produced by RunLengthEncode.
+type Run[T any] struct {
+	Value T
+	Count int
+}
+
+// RunLengthEncode collapses each run of consecutive equal elements of s
+// into a single Run recording the element and the length of the run.
+// Where Compact keeps one copy of each run, RunLengthEncode also counts
+// it. The result is empty if s is empty.
+func RunLengthEncode[T comparable](s []T) []Run[T] {
+	runs := []Run[T]{}
+	for _, v := range s {
+		if n := len(runs); n > 0 && runs[n-1].Value == v {
+			runs[n-1].Count++
+		} else {
+			runs = append(runs, Run[T]{v, 1})
+		}
+	}
+	return runs
+}
+
+// RunLengthDecode expands runs back into the slice they describe, the
+// inverse of RunLengthEncode. It panics if any Count is negative.
+func RunLengthDecode[T any](runs []Run[T]) []T {
+	n := 0
+	for _, r := range runs {
+		if r.Count < 0 {
+			panic("slices.RunLengthDecode: negative count")
+		}
+		n += r.Count
+	}
+	s := make([]T, 0, n)
+	for _, r := range runs {
+		for i := 0; i < r.Count; i++ {
+			s = append(s, r.Value)
+		}
+	}
+	return s
+}
+
 // Grow grows the slice's capacity, if necessary, to guarantee space for
 // another n elements. After Grow(n), at least n elements can be appended
-// to the slice without another allocation. If n is negative or too large to
-// allocate the memory, Grow will panic.
+// to the slice without another allocation. If s already has room for n more
+// elements it is returned unchanged. Otherwise the new capacity is rounded
+// up, doubling small slices and growing large ones by about 1.25x, so that
+// repeated calls to Grow take amortized constant time per element.
+// If n is negative or too large to allocate the memory, Grow will panic.
 func Grow[S constraints.Slice[T], T any](s S, n int) S {
-	return append(s[:cap(s)], make([]T, n, n)...)[:len(s)]
+	if n < 0 {
+		panic("slices.Grow: negative count")
+	}
+	if cap(s)-len(s) >= n {
+		return s
+	}
+	need := len(s) + n
+	if need < 0 {
+		panic("slices.Grow: count too large")
+	}
+	s2 := make(S, len(s), growCap(cap(s), need))
+	copy(s2, s)
+	return s2
+}
+
+// growCap returns a capacity of at least need for a slice whose current
+// capacity is old, following the same policy as append: double while the
+// capacity is small, then grow by a quarter plus a constant.
+func growCap(old, need int) int {
+	const threshold = 256
+	c := old
+	if c == 0 {
+		return need
+	}
+	for c < need {
+		if c < threshold {
+			c *= 2
+		} else {
+			c += (c + 3*threshold) / 4
+		}
+		if c <= 0 {
+			// Overflow; settle for exactly what was asked.
+			return need
+		}
+	}
+	return c
 }
 
 // Clip removes unused capacity from the slice, returning s[:len(s):len(s)].
 func Clip[S constraints.Slice[T], T any](s S) S {
 	return s[:len(s):len(s)]
 }
+
+// Truncate shortens s to its first n elements, returning s[:n]. Reslicing
+// alone keeps the dropped elements reachable through the backing array,
+// and Clip does not help because the capacity still covers it, so for
+// pointer-bearing element types whatever they reference could never be
+// garbage collected. Truncate therefore sets s[n:len(s)] to the zero value
+// first. It panics if n is negative or greater than len(s).
+func Truncate[S constraints.Slice[T], T any](s S, n int) S {
+	if n < 0 || n > len(s) {
+		panic("slices.Truncate: length out of range")
+	}
+	clearSlice(s[n:])
+	return s[:n]
+}
+
+// Min returns the minimal value in s. It panics if s is empty.
+// For floating-point numbers, Min propagates NaNs (any NaN value in s
+// forces the output to be NaN).
+func Min[T constraints.Ordered](s []T) T {
+	if len(s) < 1 {
+		panic("slices.Min: empty list")
+	}
+	m := s[0]
+	for i := 1; i < len(s); i++ {
+		v := s[i]
+		if isNaN(v) {
+			return v
+		}
+		if v < m {
+			m = v
+		}
+	}
+	return m
+}
+
+// Max returns the maximal value in s. It panics if s is empty.
+// For floating-point numbers, Max propagates NaNs (any NaN value in s
+// forces the output to be NaN).
+func Max[T constraints.Ordered](s []T) T {
+	if len(s) < 1 {
+		panic("slices.Max: empty list")
+	}
+	m := s[0]
+	for i := 1; i < len(s); i++ {
+		v := s[i]
+		if isNaN(v) {
+			return v
+		}
+		if v > m {
+			m = v
+		}
+	}
+	return m
+}
+
+// MinFunc returns the minimal value in s, using less to compare elements.
+// It panics if s is empty. If there is more than one minimal element
+// according to less, MinFunc returns the first one.
+func MinFunc[T any](s []T, less func(a, b T) bool) T {
+	if len(s) < 1 {
+		panic("slices.MinFunc: empty list")
+	}
+	m := s[0]
+	for i := 1; i < len(s); i++ {
+		if less(s[i], m) {
+			m = s[i]
+		}
+	}
+	return m
+}
+
+// MaxFunc returns the maximal value in s, using less to compare elements.
+// It panics if s is empty. If there is more than one maximal element
+// according to less, MaxFunc returns the first one.
+func MaxFunc[T any](s []T, less func(a, b T) bool) T {
+	if len(s) < 1 {
+		panic("slices.MaxFunc: empty list")
+	}
+	m := s[0]
+	for i := 1; i < len(s); i++ {
+		if less(m, s[i]) {
+			m = s[i]
+		}
+	}
+	return m
+}
+
+// MinMax returns both the minimal and the maximal value in s in a single
+// pass. It panics if s is empty.
+// Elements are examined in pairs: the smaller of each pair is compared
+// against the running minimum and the larger against the running maximum,
+// which takes about 1.5 comparisons per element instead of the 2 needed by
+// calling Min and Max separately.
+// For floating-point numbers, MinMax propagates NaNs (any NaN value in s
+// forces both outputs to be NaN).
+func MinMax[T constraints.Ordered](s []T) (min, max T) {
+	n := len(s)
+	if n < 1 {
+		panic("slices.MinMax: empty list")
+	}
+	min, max = s[0], s[0]
+	if isNaN(min) {
+		return min, min
+	}
+	i := 1
+	for ; i+1 < n; i += 2 {
+		a, b := s[i], s[i+1]
+		if isNaN(a) {
+			return a, a
+		}
+		if isNaN(b) {
+			return b, b
+		}
+		if b < a {
+			a, b = b, a
+		}
+		if a < min {
+			min = a
+		}
+		if b > max {
+			max = b
+		}
+	}
+	if i < n {
+		v := s[i]
+		if isNaN(v) {
+			return v, v
+		}
+		if v < min {
+			min = v
+		} else if v > max {
+			max = v
+		}
+	}
+	return min, max
+}
+
+// MinMaxFunc is like MinMax, but uses less to compare elements.
+// It panics if s is empty. As with MinFunc and MaxFunc, if there is more
+// than one minimal or maximal element according to less, the first one
+// is returned.
+func MinMaxFunc[T any](s []T, less func(a, b T) bool) (min, max T) {
+	n := len(s)
+	if n < 1 {
+		panic("slices.MinMaxFunc: empty list")
+	}
+	min, max = s[0], s[0]
+	i := 1
+	for ; i+1 < n; i += 2 {
+		a, b := s[i], s[i+1]
+		if less(b, a) {
+			if less(b, min) {
+				min = b
+			}
+			if less(max, a) {
+				max = a
+			}
+			continue
+		}
+		if less(a, min) {
+			min = a
+		}
+		if less(max, b) {
+			// a and b may be equal; the earlier one wins.
+			if less(a, b) {
+				max = b
+			} else {
+				max = a
+			}
+		}
+	}
+	if i < n {
+		v := s[i]
+		if less(v, min) {
+			min = v
+		} else if less(max, v) {
+			max = v
+		}
+	}
+	return min, max
+}
+
+// ArgMin returns the index of the minimal value in s, or -1 if s is empty.
+// If there is more than one minimal element, ArgMin returns the index of
+// the first one. For floating-point numbers, consistent with Min, the index
+// of the first NaN is returned if s contains any.
+func ArgMin[T constraints.Ordered](s []T) int {
+	if len(s) < 1 {
+		return -1
+	}
+	m := 0
+	for i := 0; i < len(s); i++ {
+		v := s[i]
+		if isNaN(v) {
+			return i
+		}
+		if v < s[m] {
+			m = i
+		}
+	}
+	return m
+}
+
+// ArgMax returns the index of the maximal value in s, or -1 if s is empty.
+// If there is more than one maximal element, ArgMax returns the index of
+// the first one. For floating-point numbers, consistent with Max, the index
+// of the first NaN is returned if s contains any.
+func ArgMax[T constraints.Ordered](s []T) int {
+	if len(s) < 1 {
+		return -1
+	}
+	m := 0
+	for i := 0; i < len(s); i++ {
+		v := s[i]
+		if isNaN(v) {
+			return i
+		}
+		if v > s[m] {
+			m = i
+		}
+	}
+	return m
+}
+
+// ArgMinFunc is like ArgMin, but uses less to compare elements.
+func ArgMinFunc[T any](s []T, less func(a, b T) bool) int {
+	if len(s) < 1 {
+		return -1
+	}
+	m := 0
+	for i := 1; i < len(s); i++ {
+		if less(s[i], s[m]) {
+			m = i
+		}
+	}
+	return m
+}
+
+// ArgMaxFunc is like ArgMax, but uses less to compare elements.
+func ArgMaxFunc[T any](s []T, less func(a, b T) bool) int {
+	if len(s) < 1 {
+		return -1
+	}
+	m := 0
+	for i := 1; i < len(s); i++ {
+		if less(s[m], s[i]) {
+			m = i
+		}
+	}
+	return m
+}
+
+// Reverse reverses the elements of the slice in place.
+func Reverse[S constraints.Slice[T], T any](s S) {
+	for i, j := 0, len(s)-1; i < j; i, j = i+1, j-1 {
+		s[i], s[j] = s[j], s[i]
+	}
+}
+
+// Reversed returns a new slice holding the elements of s in reverse order.
+// Unlike Reverse, s is left unmodified and the result does not share
+// its backing array with s.
+func Reversed[S constraints.Slice[T], T any](s S) S {
+	s2 := make(S, len(s))
+	for i, v := range s {
+		s2[len(s)-1-i] = v
+	}
+	return s2
+}
+
+// Map returns a new slice holding the result of applying f to each
+// element of s, in order. If s is nil, Map returns nil; otherwise the
+// result has the same length as s, so an empty non-nil s yields an empty
+// non-nil slice.
+func Map[T, U any](s []T, f func(T) U) []U {
+	if s == nil {
+		return nil
+	}
+	s2 := make([]U, len(s))
+	for i, v := range s {
+		s2[i] = f(v)
+	}
+	return s2
+}
+
+// MapInPlace is like Map for functions from T to T, but overwrites each
+// element of s with f applied to it instead of allocating a new slice.
+// It returns s, so calls can be chained.
+func MapInPlace[S constraints.Slice[T], T any](s S, f func(T) T) S {
+	for i, v := range s {
+		s[i] = f(v)
+	}
+	return s
+}
+
+// MapErr is like Map, but f may fail. MapErr stops at the first element for
+// which f returns a non-nil error, discards the results converted so far and
+// returns that error wrapped with the index of the failing element.
+// f is not called for any element after the failing one.
+func MapErr[T, U any](s []T, f func(T) (U, error)) ([]U, error) {
+	if s == nil {
+		return nil, nil
+	}
+	s2 := make([]U, len(s))
+	for i, v := range s {
+		u, err := f(v)
+		if err != nil {
+			return nil, fmt.Errorf("index %d: %w", i, err)
+		}
+		s2[i] = u
+	}
+	return s2, nil
+}
+
+// Filter returns a new slice holding the elements of s for which keep
+// returns true, in their original order. s is not modified, and keep is
+// called exactly once for each element. If no element matches, Filter
+// returns nil.
+func Filter[S constraints.Slice[T], T any](s S, keep func(T) bool) S {
+	var s2 S
+	for _, v := range s {
+		if keep(v) {
+			s2 = append(s2, v)
+		}
+	}
+	return s2
+}
+
+// FilterInPlace keeps only the elements of s for which keep returns true,
+// moving them to the front of s in their original order, and returns the
+// shortened slice. The elements between the new length and the original
+// length are set to the zero value so that any values they referenced can
+// be garbage collected.
+// FilterInPlace destroys the contents of s: after the call, the input must
+// not be used, only the returned slice.
+func FilterInPlace[S constraints.Slice[T], T any](s S, keep func(T) bool) S {
+	j := 0
+	for i, v := range s {
+		if !keep(v) {
+			continue
+		}
+		if i != j {
+			s[j] = v
+		}
+		j++
+	}
+	clearSlice(s[j:])
+	return s[:j]
+}
+
+// clearSlice sets every element of s to the zero value of T.
+func clearSlice[T any](s []T) {
+	var zero T
+	for i := range s {
+		s[i] = zero
+	}
+}
+
+// Reduce combines the elements of s into a single value by calling f on
+// an accumulator and each element in turn, from left to right, starting
+// with init. The accumulator type A is independent of the element type.
+// If s is empty, Reduce returns init.
+func Reduce[T, A any](s []T, init A, f func(acc A, v T) A) A {
+	acc := init
+	for _, v := range s {
+		acc = f(acc, v)
+	}
+	return acc
+}
+
+// ReduceRight is like Reduce, but walks s from the last element to the
+// first, which makes it suitable for building right-associative results.
+// Note that f receives the element before the accumulator.
+// If s is empty, ReduceRight returns init.
+func ReduceRight[T, A any](s []T, init A, f func(v T, acc A) A) A {
+	acc := init
+	for i := len(s) - 1; i >= 0; i-- {
+		acc = f(s[i], acc)
+	}
+	return acc
+}
+
+// FlatMap applies f to each element of s and returns the concatenation of
+// the resulting slices, in order. Empty results contribute nothing.
+// The inner slices are kept until all of them are known so that the output
+// can be allocated once with the exact total length; f is still called only
+// once per element.
+func FlatMap[T, U any](s []T, f func(T) []U) []U {
+	parts := make([][]U, len(s))
+	n := 0
+	for i, v := range s {
+		parts[i] = f(v)
+		n += len(parts[i])
+	}
+	s2 := make([]U, 0, n)
+	for _, p := range parts {
+		s2 = append(s2, p...)
+	}
+	return s2
+}
+
+// FilterMap applies f to each element of s and returns the mapped values
+// for which f also reported true, in order. It is equivalent to Filter
+// followed by Map without allocating the intermediate slice.
+// If f reports false for every element, FilterMap returns nil.
+func FilterMap[T, U any](s []T, f func(T) (U, bool)) []U {
+	var s2 []U
+	for _, v := range s {
+		if u, ok := f(v); ok {
+			s2 = append(s2, u)
+		}
+	}
+	return s2
+}
+
+// ForEach calls f for each element of s, in order.
+func ForEach[T any](s []T, f func(T)) {
+	for _, v := range s {
+		f(v)
+	}
+}
+
+// ForEachIndexed calls f with the index and a copy of each element of s,
+// in order.
+func ForEachIndexed[T any](s []T, f func(i int, v T)) {
+	for i, v := range s {
+		f(i, v)
+	}
+}
+
+// ForEachPtr calls f with a pointer to each element of s, in order, so that
+// f can update the elements in place without copying them.
+func ForEachPtr[T any](s []T, f func(*T)) {
+	for i := range s {
+		f(&s[i])
+	}
+}
+
+// Chunk splits s into consecutive sub-slices of length size; the final
+// chunk is shorter if len(s) is not a multiple of size.
+// Chunk panics if size is less than 1. If s is empty, the result is empty.
+// The chunks are sub-slices of s and share its backing array: modifying an
+// element of a chunk modifies s. Each chunk's capacity is limited to its
+// length, so appending to a chunk never overwrites the following one.
+func Chunk[S constraints.Slice[T], T any](s S, size int) []S {
+	if size < 1 {
+		panic("slices.Chunk: size must be positive")
+	}
+	chunks := make([]S, 0, (len(s)+size-1)/size)
+	for i := 0; i < len(s); i += size {
+		end := len(s)
+		if size < end-i {
+			end = i + size
+		}
+		chunks = append(chunks, s[i:end:end])
+	}
+	return chunks
+}
+
+// ChunkBy splits s into maximal runs of consecutive elements for which key
+// returns the same value. Unlike GroupBy, it keeps adjacency: two runs with
+// the same key separated by other elements become two chunks. key is
+// called exactly once per element. If s is empty, the result is empty.
+// The chunks share the backing array of s, as with Chunk.
+func ChunkBy[S constraints.Slice[T], T any, K comparable](s S, key func(T) K) []S {
+	chunks := []S{}
+	if len(s) == 0 {
+		return chunks
+	}
+	start := 0
+	k := key(s[0])
+	for i := 1; i < len(s); i++ {
+		if ki := key(s[i]); ki != k {
+			chunks = append(chunks, s[start:i:i])
+			start, k = i, ki
+		}
+	}
+	return append(chunks, s[start:len(s):len(s)])
+}
+
+// Window returns all contiguous sub-slices of s of length size, starting
+// at successive indices 0, 1, 2, .... If len(s) < size the result is empty.
+// Window panics if size is less than 1.
+// The windows share the backing array of s, as with Chunk.
+func Window[S constraints.Slice[T], T any](s S, size int) []S {
+	return WindowStep(s, size, 1)
+}
+
+// WindowStep is like Window, but the start of each window is step elements
+// after the start of the previous one. A step larger than size skips the
+// elements between windows. WindowStep panics if size or step is less than 1.
+func WindowStep[S constraints.Slice[T], T any](s S, size, step int) []S {
+	if size < 1 {
+		panic("slices.WindowStep: size must be positive")
+	}
+	if step < 1 {
+		panic("slices.WindowStep: step must be positive")
+	}
+	if len(s) < size {
+		return []S{}
+	}
+	windows := make([]S, 0, (len(s)-size)/step+1)
+	for i := 0; i+size <= len(s); i += step {
+		windows = append(windows, s[i:i+size:i+size])
+	}
+	return windows
+}
+
+// Flatten returns a newly allocated slice holding the elements of each
+// inner slice of ss, in order. The result is allocated once, with its
+// length computed up front. Nil inner slices are skipped.
+func Flatten[T any](ss [][]T) []T {
+	n := 0
+	for _, s := range ss {
+		n += len(s)
+	}
+	s2 := make([]T, 0, n)
+	for _, s := range ss {
+		s2 = append(s2, s...)
+	}
+	return s2
+}
+
+// Concat returns a new slice holding the elements of each of ss, in order.
+// The result is always newly allocated, even when only one slice is given,
+// so it never aliases any of the inputs. With no arguments, Concat returns
+// an empty slice.
+func Concat[S constraints.Slice[T], T any](ss ...S) S {
+	n := 0
+	for _, s := range ss {
+		n += len(s)
+	}
+	s2 := make(S, 0, n)
+	for _, s := range ss {
+		s2 = append(s2, s...)
+	}
+	return s2
+}
+
+// Repeat returns a new slice holding n copies of v.
+// It panics if n is negative.
+func Repeat[T any](v T, n int) []T {
+	if n < 0 {
+		panic("slices.Repeat: negative count")
+	}
+	s := make([]T, n)
+	for i := range s {
+		s[i] = v
+	}
+	return s
+}
+
+// RepeatSlice returns a new slice holding n copies of s, one after another.
+// It panics if n is negative or if len(s) * n overflows.
+func RepeatSlice[S constraints.Slice[T], T any](s S, n int) S {
+	if n < 0 {
+		panic("slices.RepeatSlice: negative count")
+	}
+	if len(s) > 0 && n > math.MaxInt/len(s) {
+		panic("slices.RepeatSlice: len(s) * n overflows")
+	}
+	s2 := make(S, len(s)*n)
+	// Double the copied prefix each time to keep the number of copy calls
+	// logarithmic in n.
+	m := copy(s2, s)
+	for m < len(s2) {
+		m += copy(s2[m:], s2[:m])
+	}
+	return s2
+}
+
+// Fill assigns v to every element of s.
+func Fill[T any](s []T, v T) {
+	for i := range s {
+		s[i] = v
+	}
+}
+
+// FillRange assigns v to the elements s[i:j].
+// FillRange panics if s[i:j] is not a valid slice of s.
+func FillRange[T any](s []T, v T, i, j int) {
+	Fill(s[i:j:len(s)], v)
+}
+
+// Pair holds two values of possibly different types.
+type Pair[A, B any] struct {
+	First  A
+	Second B
+}
+
+// Zip returns a slice of pairs whose i-th element holds a[i] and b[i].
+// The result has the length of the shorter input; the remaining elements
+// of the longer input are ignored.
+func Zip[A, B any](a []A, b []B) []Pair[A, B] {
+	n := len(a)
+	if len(b) < n {
+		n = len(b)
+	}
+	ps := make([]Pair[A, B], n)
+	for i := range ps {
+		ps[i] = Pair[A, B]{a[i], b[i]}
+	}
+	return ps
+}
+
+// ZipLongest is like Zip, but the result has the length of the longer
+// input. The missing elements of the shorter input are replaced by fillA
+// or fillB respectively.
+func ZipLongest[A, B any](a []A, b []B, fillA A, fillB B) []Pair[A, B] {
+	n := len(a)
+	if len(b) > n {
+		n = len(b)
+	}
+	ps := make([]Pair[A, B], n)
+	for i := range ps {
+		p := Pair[A, B]{fillA, fillB}
+		if i < len(a) {
+			p.First = a[i]
+		}
+		if i < len(b) {
+			p.Second = b[i]
+		}
+		ps[i] = p
+	}
+	return ps
+}
+
+// ZipWith returns a slice whose i-th element is f(a[i], b[i]).
+// The result has the length of the shorter input, and f is called exactly
+// once for each index of it.
+func ZipWith[A, B, C any](a []A, b []B, f func(A, B) C) []C {
+	n := len(a)
+	if len(b) < n {
+		n = len(b)
+	}
+	s := make([]C, n)
+	for i := range s {
+		s[i] = f(a[i], b[i])
+	}
+	return s
+}
+
+// Unzip splits a slice of pairs into a slice of their first values and a
+// slice of their second values, both in the original order.
+// It is the inverse of Zip.
+func Unzip[A, B any](ps []Pair[A, B]) ([]A, []B) {
+	a := make([]A, len(ps))
+	b := make([]B, len(ps))
+	for i, p := range ps {
+		a[i] = p.First
+		b[i] = p.Second
+	}
+	return a, b
+}
+
+// Pairwise returns the pairs of adjacent elements of s: (s[0], s[1]),
+// (s[1], s[2]), and so on. The result has len(s)-1 elements, and is empty
+// if s has fewer than two.
+func Pairwise[T any](s []T) []Pair[T, T] {
+	return PairwiseFunc(s, func(prev, cur T) Pair[T, T] { return Pair[T, T]{prev, cur} })
+}
+
+// PairwiseFunc returns a slice whose i-th element is f(s[i], s[i+1]), such
+// as the differences between consecutive samples, without building the
+// intermediate pairs. The result is empty if s has fewer than two elements.
+func PairwiseFunc[T, U any](s []T, f func(prev, cur T) U) []U {
+	if len(s) < 2 {
+		return []U{}
+	}
+	s2 := make([]U, len(s)-1)
+	for i := range s2 {
+		s2[i] = f(s[i], s[i+1])
+	}
+	return s2
+}
+
+// GroupBy groups the elements of s by the key returned by key. Within each
+// group the elements keep their order in s. If s is empty, GroupBy returns
+// an empty, non-nil map.
+func GroupBy[T any, K comparable](s []T, key func(T) K) map[K][]T {
+	m := make(map[K][]T)
+	for _, v := range s {
+		k := key(v)
+		m[k] = append(m[k], v)
+	}
+	return m
+}
+
+// Partition splits s into two new slices: the elements for which pred
+// returns true and those for which it returns false, each in their
+// original order. s is not modified.
+func Partition[S constraints.Slice[T], T any](s S, pred func(T) bool) (yes, no S) {
+	for _, v := range s {
+		if pred(v) {
+			yes = append(yes, v)
+		} else {
+			no = append(no, v)
+		}
+	}
+	return yes, no
+}
+
+// Unique returns a new slice holding the first occurrence of each distinct
+// value of s, in their original order. Unlike Compact, duplicates need not
+// be adjacent, so s does not have to be sorted first.
+func Unique[S constraints.Slice[T], T comparable](s S) S {
+	seen := make(map[T]struct{})
+	var s2 S
+	for _, v := range s {
+		if _, ok := seen[v]; ok {
+			continue
+		}
+		seen[v] = struct{}{}
+		s2 = append(s2, v)
+	}
+	return s2
+}
+
+// UniqueBy is like Unique, but two elements are considered duplicates when
+// key returns the same value for them. The first element seen for each key
+// is kept, so T itself need not be comparable.
+func UniqueBy[S constraints.Slice[T], T any, K comparable](s S, key func(T) K) S {
+	seen := make(map[K]struct{})
+	var s2 S
+	for _, v := range s {
+		k := key(v)
+		if _, ok := seen[k]; ok {
+			continue
+		}
+		seen[k] = struct{}{}
+		s2 = append(s2, v)
+	}
+	return s2
+}
+
+// Without returns a new slice holding the elements of s that are not equal
+// to any of vs, in their original order. s is not modified.
+// When len(s)*len(vs) is large, a set is built from vs for the membership
+// tests.
+func Without[S constraints.Slice[T], T comparable](s S, vs ...T) S {
+	var remove func(T) bool
+	if useSet(len(s), len(vs)) {
+		set := ToSet(vs)
+		remove = func(v T) bool {
+			_, ok := set[v]
+			return ok
+		}
+	} else {
+		remove = func(v T) bool { return Contains(vs, v) }
+	}
+	s2 := make(S, 0, len(s))
+	for _, v := range s {
+		if !remove(v) {
+			s2 = append(s2, v)
+		}
+	}
+	return s2
+}
+
+// ReplaceAll replaces every element of s equal to old by new, in place,
+// and returns s.
+func ReplaceAll[S constraints.Slice[T], T comparable](s S, old, new T) S {
+	for i := range s {
+		if s[i] == old {
+			s[i] = new
+		}
+	}
+	return s
+}
+
+// ReplaceAllFunc replaces every element e of s satisfying pred(e) by new,
+// in place, and returns s.
+func ReplaceAllFunc[S constraints.Slice[T], T any](s S, pred func(T) bool, new T) S {
+	for i := range s {
+		if pred(s[i]) {
+			s[i] = new
+		}
+	}
+	return s
+}
+
+// Rotate rotates the elements of s in place to the left by k positions, so
+// that the element at index k moves to index 0. A negative k rotates to the
+// right. k is taken modulo len(s).
+// Rotate runs in O(len(s)) time and uses O(1) extra space.
+func Rotate[S constraints.Slice[T], T any](s S, k int) {
+	n := len(s)
+	if n == 0 {
+		return
+	}
+	k %= n
+	if k < 0 {
+		k += n
+	}
+	if k == 0 {
+		return
+	}
+	Reverse(s[:k])
+	Reverse(s[k:])
+	Reverse(s)
+}
+
+// Swap exchanges the elements at indices i and j of s.
+// It panics, naming the offending index, if either is out of range.
+func Swap[T any](s []T, i, j int) {
+	for _, k := range [2]int{i, j} {
+		if k < 0 || k >= len(s) {
+			panic(fmt.Sprintf("slices.Swap: index %d out of range [0:%d]", k, len(s)))
+		}
+	}
+	s[i], s[j] = s[j], s[i]
+}
+
+// MoveElement moves the element at index from to index to in place,
+// shifting the elements between them by one position, and returns s.
+// Afterwards the moved element is at index to, and the relative order of
+// all other elements is unchanged. MoveElement does nothing if from == to.
+// It panics if either index is out of range.
+func MoveElement[S constraints.Slice[T], T any](s S, from, to int) S {
+	if from < 0 || from >= len(s) || to < 0 || to >= len(s) {
+		panic("slices.MoveElement: index out of range")
+	}
+	v := s[from]
+	if from < to {
+		copy(s[from:to], s[from+1:to+1])
+	} else {
+		copy(s[to+1:from+1], s[to:from])
+	}
+	s[to] = v
+	return s
+}
+
+// Take returns a copy of the first n elements of s. n is clamped to the
+// range [0, len(s)], so Take never panics.
+func Take[S constraints.Slice[T], T any](s S, n int) S {
+	n = clamp(n, len(s))
+	return Clone(s[:n:n])
+}
+
+// Drop returns a copy of s without its first n elements. n is clamped to
+// the range [0, len(s)], so Drop never panics.
+func Drop[S constraints.Slice[T], T any](s S, n int) S {
+	return Clone(s[clamp(n, len(s)):len(s):len(s)])
+}
+
+// TakeWhile returns a copy of the longest prefix of s whose elements all
+// satisfy pred.
+func TakeWhile[S constraints.Slice[T], T any](s S, pred func(T) bool) S {
+	n := prefixLen(s, pred)
+	return Clone(s[:n:n])
+}
+
+// DropWhile returns a copy of s without the longest prefix whose elements
+// all satisfy pred; the result starts at the first element failing pred.
+func DropWhile[S constraints.Slice[T], T any](s S, pred func(T) bool) S {
+	return Clone(s[prefixLen(s, pred):len(s):len(s)])
+}
+
+// clamp returns n limited to the range [0, max].
+func clamp(n, max int) int {
+	if n < 0 {
+		return 0
+	}
+	if n > max {
+		return max
+	}
+	return n
+}
+
+// prefixLen returns the index of the first element of s not satisfying
+// pred, or len(s) if all of them do.
+func prefixLen[T any](s []T, pred func(T) bool) int {
+	if i := IndexFunc(s, func(v T) bool { return !pred(v) }); i >= 0 {
+		return i
+	}
+	return len(s)
+}
+
+// All reports whether every element of s satisfies pred. It stops at the
+// first element that does not. All returns true for an empty slice.
+func All[T any](s []T, pred func(T) bool) bool {
+	for _, v := range s {
+		if !pred(v) {
+			return false
+		}
+	}
+	return true
+}
+
+// Any reports whether at least one element of s satisfies pred. It stops
+// at the first element that does. Any returns false for an empty slice.
+func Any[T any](s []T, pred func(T) bool) bool {
+	return ContainsFunc(s, pred)
+}
+
+// None reports whether no element of s satisfies pred. It stops at the
+// first element that does. None returns true for an empty slice.
+func None[T any](s []T, pred func(T) bool) bool {
+	return !ContainsFunc(s, pred)
+}
+
+// Scan is like Reduce, but returns every intermediate accumulator: the
+// result r has the same length as s, with r[i] holding the accumulator after
+// f has been applied to s[i]. The last element of a non-empty result thus
+// equals Reduce(s, init, f). Elements are processed from left to right.
+func Scan[T, A any](s []T, init A, f func(acc A, v T) A) []A {
+	r := make([]A, len(s))
+	acc := init
+	for i, v := range s {
+		acc = f(acc, v)
+		r[i] = acc
+	}
+	return r
+}
+
+// ToMap returns a map from key(e) to e for each element e of s.
+// If several elements have the same key, the last one wins.
+func ToMap[T any, K comparable](s []T, key func(T) K) map[K]T {
+	m := make(map[K]T, len(s))
+	for _, v := range s {
+		m[key(v)] = v
+	}
+	return m
+}
+
+// ToMapValue returns a map holding the key/value pair kv(e) for each element
+// e of s. If several elements produce the same key, the last one wins.
+func ToMapValue[T any, K comparable, V any](s []T, kv func(T) (K, V)) map[K]V {
+	m := make(map[K]V, len(s))
+	for _, v := range s {
+		k, val := kv(v)
+		m[k] = val
+	}
+	return m
+}
+
+// ToSet returns a set holding the distinct elements of s, for O(1)
+// membership tests.
+func ToSet[T comparable](s []T) map[T]struct{} {
+	set := make(map[T]struct{}, len(s))
+	for _, v := range s {
+		set[v] = struct{}{}
+	}
+	return set
+}
+
+// SetToSlice returns the elements of set as a new slice.
+// The order of the elements is unspecified.
+func SetToSlice[T comparable](set map[T]struct{}) []T {
+	s := make([]T, 0, len(set))
+	for v := range set {
+		s = append(s, v)
+	}
+	return s
+}
+
+// Frequencies returns a map from each distinct element of s to the number
+// of times it occurs in s.
+func Frequencies[T comparable](s []T) map[T]int {
+	m := make(map[T]int)
+	for _, v := range s {
+		m[v]++
+	}
+	return m
+}
+
+// FrequenciesBy returns a map from each distinct key(e) to the number of
+// elements e of s producing that key.
+func FrequenciesBy[T any, K comparable](s []T, key func(T) K) map[K]int {
+	m := make(map[K]int)
+	for _, v := range s {
+		m[key(v)]++
+	}
+	return m
+}
+
+// Interleave returns a new slice taking elements from each of ss in turn:
+// ss[0][0], ss[1][0], ..., ss[0][1], ss[1][1], and so on. Once a slice is
+// exhausted it is skipped, and the remaining slices continue to take turns.
+// With no arguments, Interleave returns an empty slice.
+func Interleave[S constraints.Slice[T], T any](ss ...S) S {
+	n, longest := 0, 0
+	for _, s := range ss {
+		n += len(s)
+		if len(s) > longest {
+			longest = len(s)
+		}
+	}
+	s2 := make(S, 0, n)
+	for i := 0; i < longest; i++ {
+		for _, s := range ss {
+			if i < len(s) {
+				s2 = append(s2, s[i])
+			}
+		}
+	}
+	return s2
+}
+
+// Intersect returns a new slice holding the distinct elements of a that
+// are also present in b, in the order of their first occurrence in a.
+// It builds a set over the smaller of the two inputs, so it runs in
+// O(len(a)+len(b)) time.
+func Intersect[S constraints.Slice[T], T comparable](a, b S) S {
+	var s2 S
+	if len(b) <= len(a) {
+		set := ToSet(b)
+		for _, v := range a {
+			if _, ok := set[v]; ok {
+				s2 = append(s2, v)
+				delete(set, v) // emit each value once
+			}
+		}
+		return s2
+	}
+	// Record which elements of the smaller a occur in b, then emit them
+	// in a's order.
+	set := ToSet(a)
+	found := make(map[T]struct{}, len(set))
+	for _, v := range b {
+		if _, ok := set[v]; ok {
+			found[v] = struct{}{}
+		}
+	}
+	for _, v := range a {
+		if _, ok := found[v]; ok {
+			s2 = append(s2, v)
+			delete(found, v)
+		}
+	}
+	return s2
+}
+
+// Union returns a new slice holding the distinct elements of all of ss, in
+// the order in which they are first seen. Like Unique and Intersect, the
+// first occurrence of each value wins. Union of a single slice is
+// equivalent to Unique.
+func Union[S constraints.Slice[T], T comparable](ss ...S) S {
+	seen := make(map[T]struct{})
+	var s2 S
+	for _, s := range ss {
+		for _, v := range s {
+			if _, ok := seen[v]; ok {
+				continue
+			}
+			seen[v] = struct{}{}
+			s2 = append(s2, v)
+		}
+	}
+	return s2
+}
+
+// Difference returns a new slice holding the distinct elements of a that
+// are not present in b, in the order of their first occurrence in a.
+// Duplicates in a are removed, as in Unique.
+func Difference[S constraints.Slice[T], T comparable](a, b S) S {
+	exclude := ToSet(b)
+	var s2 S
+	for _, v := range a {
+		if _, ok := exclude[v]; ok {
+			continue
+		}
+		exclude[v] = struct{}{} // emit each value once
+		s2 = append(s2, v)
+	}
+	return s2
+}
+
+// SymmetricDifference returns a new slice holding the distinct elements that
+// are present in exactly one of a and b: first those of a, in a's order,
+// then those of b, in b's order.
+func SymmetricDifference[S constraints.Slice[T], T comparable](a, b S) S {
+	return append(Difference(a, b), Difference(b, a)...)
+}
+
+// LongestCommonPrefix returns the longest prefix shared by all of ss.
+// The result is a sub-slice of ss[0] and shares its backing array.
+// With no arguments, LongestCommonPrefix returns nil.
+func LongestCommonPrefix[S constraints.Slice[T], T comparable](ss ...S) S {
+	if len(ss) == 0 {
+		return nil
+	}
+	prefix := ss[0]
+	for _, s := range ss[1:] {
+		n := len(prefix)
+		if len(s) < n {
+			n = len(s)
+		}
+		i := 0
+		for i < n && prefix[i] == s[i] {
+			i++
+		}
+		prefix = prefix[:i]
+	}
+	return prefix
+}
+
+// Split slices s into all sub-slices separated by sep and returns a slice
+// of the sub-slices between those separators. Adjacent separators, and
+// separators at either end of s, produce empty sub-slices, so the result
+// always has one more element than there are separators in s. As with
+// strings.Split, if sep does not occur in s (including when s is empty),
+// the result has a single element, s itself.
+// The sub-slices share the backing array of s; each one's capacity is
+// limited to its length.
+func Split[S constraints.Slice[T], T comparable](s S, sep T) []S {
+	return SplitN(s, sep, -1)
+}
+
+// SplitN is like Split but returns at most n sub-slices; the last one is
+// the unsplit remainder of s. If n is zero, SplitN returns nil, and if n is
+// negative, all sub-slices are returned, as with strings.SplitN.
+func SplitN[S constraints.Slice[T], T comparable](s S, sep T, n int) []S {
+	return SplitFuncN(s, func(v T) bool { return v == sep }, n)
+}
+
+// SplitFunc is like Split but splits s at each element for which isSep
+// returns true. Empty sub-slices are kept, exactly as in Split.
+func SplitFunc[S constraints.Slice[T], T any](s S, isSep func(T) bool) []S {
+	return SplitFuncN(s, isSep, -1)
+}
+
+// SplitFuncN is like SplitFunc but returns at most n sub-slices, with the
+// same meaning of n as in SplitN.
+func SplitFuncN[S constraints.Slice[T], T any](s S, isSep func(T) bool, n int) []S {
+	if n == 0 {
+		return nil
+	}
+	var parts []S
+	start := 0
+	for i, v := range s {
+		if n > 0 && len(parts) == n-1 {
+			break
+		}
+		if isSep(v) {
+			parts = append(parts, s[start:i:i])
+			start = i + 1
+		}
+	}
+	return append(parts, s[start:len(s):len(s)])
+}
+
+// Join concatenates the elements of ss, placing sep between consecutive
+// slices. It is the inverse of Split: Join(Split(s, sep), sep) is equal to s.
+// As with Concat, the result is always newly allocated and sized exactly,
+// and is an empty slice when ss is empty.
+func Join[S constraints.Slice[T], T any](ss []S, sep T) S {
+	return JoinSlice(ss, S{sep})
+}
+
+// JoinSlice is like Join but places all the elements of sep between
+// consecutive slices of ss.
+func JoinSlice[S constraints.Slice[T], T any](ss []S, sep S) S {
+	if len(ss) == 0 {
+		return S{}
+	}
+	n := len(sep) * (len(ss) - 1)
+	for _, s := range ss {
+		n += len(s)
+	}
+	s2 := make(S, 0, n)
+	s2 = append(s2, ss[0]...)
+	for _, s := range ss[1:] {
+		s2 = append(s2, sep...)
+		s2 = append(s2, s...)
+	}
+	return s2
+}
@@ -1 +1,3037 @@
 package slices
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"math"
+	"math/rand"
+	"reflect"
+	"strconv"
+	"strings"
+	"testing"
+
+	"github.com/syumai/go-generics/constraints"
+)
+
+var insertTests = []struct {
+	name string
+	s    []int
+	i    int
+	v    []int
+	want []int
+}{
+	{"nil slice", nil, 0, []int{1, 2}, []int{1, 2}},
+	{"nil slice no values", nil, 0, nil, nil},
+	{"head", []int{3, 4}, 0, []int{1, 2}, []int{1, 2, 3, 4}},
+	{"middle", []int{1, 4}, 1, []int{2, 3}, []int{1, 2, 3, 4}},
+	{"append", []int{1, 2}, 2, []int{3, 4}, []int{1, 2, 3, 4}},
+	{"zero values", []int{1, 2}, 1, []int{0, 0}, []int{1, 0, 0, 2}},
+	{"no values", []int{1, 2}, 1, nil, []int{1, 2}},
+}
+
+func TestInsert(t *testing.T) {
+	for _, test := range insertTests {
+		t.Run(test.name, func(t *testing.T) {
+			s := Clone(test.s)
+			got := Insert(s, test.i, test.v...)
+			if !Equal(got, test.want) {
+				t.Errorf("Insert(%v, %d, %v...) = %v, want %v", test.s, test.i, test.v, got, test.want)
+			}
+		})
+	}
+}
+
+func TestInsertInCapacity(t *testing.T) {
+	for _, test := range insertTests {
+		t.Run(test.name, func(t *testing.T) {
+			s := make([]int, len(test.s), len(test.s)+len(test.v))
+			copy(s, test.s)
+			got := Insert(s, test.i, test.v...)
+			if !Equal(got, test.want) {
+				t.Errorf("Insert(%v, %d, %v...) = %v, want %v", test.s, test.i, test.v, got, test.want)
+			}
+			if len(got) > 0 && &got[0] != &s[:1][0] {
+				t.Errorf("Insert(%v, %d, %v...) reallocated although capacity was sufficient", test.s, test.i, test.v)
+			}
+		})
+	}
+}
+
+func TestInsertNamedSlice(t *testing.T) {
+	type strs []string
+	got := Insert(strs{"a", "c"}, 1, "b")
+	if want := (strs{"a", "b", "c"}); !Equal(got, want) {
+		t.Errorf("Insert = %v, want %v", got, want)
+	}
+}
+
+func TestInsertPanics(t *testing.T) {
+	for _, test := range []struct {
+		name string
+		s    []int
+		i    int
+	}{
+		{"negative index", []int{1, 2}, -1},
+		{"index beyond length", []int{1, 2}, 3},
+		{"index beyond length within capacity", make([]int, 2, 10), 3},
+	} {
+		t.Run(test.name, func(t *testing.T) {
+			defer func() {
+				if recover() == nil {
+					t.Errorf("Insert(%v, %d, 0) did not panic", test.s, test.i)
+				}
+			}()
+			Insert(test.s, test.i, 0)
+		})
+	}
+}
+
+func TestInsertAllocs(t *testing.T) {
+	s := make([]int, 100)
+	v := []int{1, 2, 3}
+	if n := testing.AllocsPerRun(100, func() { Insert(s, 50, v...) }); n > 1 {
+		t.Errorf("Insert reallocating: got %v allocs, want at most 1", n)
+	}
+	s = make([]int, 100, 103)
+	if n := testing.AllocsPerRun(100, func() { Insert(s, 50, v...) }); n != 0 {
+		t.Errorf("Insert within capacity: got %v allocs, want 0", n)
+	}
+}
+
+func BenchmarkInsert(b *testing.B) {
+	v := []int{1, 2, 3}
+	b.Run("InCapacity", func(b *testing.B) {
+		s := make([]int, 1000, 1003)
+		b.ReportAllocs()
+		for i := 0; i < b.N; i++ {
+			Insert(s, 500, v...)
+		}
+	})
+	b.Run("Realloc", func(b *testing.B) {
+		s := make([]int, 1000)
+		b.ReportAllocs()
+		for i := 0; i < b.N; i++ {
+			Insert(s, 500, v...)
+		}
+	})
+}
+
+func TestGrow(t *testing.T) {
+	for _, tt := range []struct {
+		len, cap, n int
+	}{
+		{0, 0, 0},
+		{0, 0, 5},
+		{3, 10, 7},
+		{3, 10, 8},
+		{100, 100, 1},
+		{1000, 1000, 1},
+		{10, 10, 100},
+	} {
+		s := make([]int, tt.len, tt.cap)
+		for i := range s {
+			s[i] = i
+		}
+		got := Grow(s, tt.n)
+		if len(got) != tt.len {
+			t.Errorf("Grow(len=%d, cap=%d, %d): len = %d, want %d", tt.len, tt.cap, tt.n, len(got), tt.len)
+		}
+		if cap(got) < tt.len+tt.n {
+			t.Errorf("Grow(len=%d, cap=%d, %d): cap = %d, want >= %d", tt.len, tt.cap, tt.n, cap(got), tt.len+tt.n)
+		}
+		if !Equal(got, s) {
+			t.Errorf("Grow(len=%d, cap=%d, %d) changed the elements", tt.len, tt.cap, tt.n)
+		}
+		if tt.cap-tt.len >= tt.n && cap(got) != tt.cap {
+			t.Errorf("Grow(len=%d, cap=%d, %d) reallocated although there was room", tt.len, tt.cap, tt.n)
+		}
+	}
+
+	s := make([]int, 5, 10)
+	if n := testing.AllocsPerRun(100, func() { Grow(s, 5) }); n != 0 {
+		t.Errorf("Grow within capacity: got %v allocs, want 0", n)
+	}
+
+	// Growing one element at a time must reallocate only O(log n) times.
+	var g []int
+	reallocs := 0
+	for i := 0; i < 10000; i++ {
+		c := cap(g)
+		g = append(Grow(g, 1), i)
+		if cap(g) != c {
+			reallocs++
+		}
+	}
+	if reallocs > 50 {
+		t.Errorf("Grow(1) in a loop reallocated %d times", reallocs)
+	}
+}
+
+func TestGrowPanics(t *testing.T) {
+	defer func() {
+		if recover() == nil {
+			t.Errorf("Grow with negative n did not panic")
+		}
+	}()
+	Grow([]int{1}, -1)
+}
+
+func BenchmarkGrowOneByOne(b *testing.B) {
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		var s []int
+		for j := 0; j < 10000; j++ {
+			s = Grow(s, 1)
+			s = append(s, j)
+		}
+	}
+}
+
+var minMaxTests = []struct {
+	data    []int
+	wantMin int
+	wantMax int
+}{
+	{[]int{7}, 7, 7},
+	{[]int{1, 2}, 1, 2},
+	{[]int{2, 1}, 1, 2},
+	{[]int{1, 2, 3}, 1, 3},
+	{[]int{3, 2, 1}, 1, 3},
+	{[]int{2, 1, 2}, 1, 2},
+	{[]int{2, 2, 2}, 2, 2},
+	{[]int{-5, 8, 0, 13, -9, 4}, -9, 13},
+}
+
+func TestCloneFunc(t *testing.T) {
+	orig := []*bytes.Buffer{bytes.NewBufferString("a"), bytes.NewBufferString("b")}
+	shallow := Clone(orig)
+	deep := CloneFunc(orig, func(b *bytes.Buffer) *bytes.Buffer {
+		return bytes.NewBuffer(append([]byte(nil), b.Bytes()...))
+	})
+	deep[0].WriteString("x")
+	if got := orig[0].String(); got != "a" {
+		t.Errorf("writing to the CloneFunc copy changed the original to %q", got)
+	}
+	shallow[1].WriteString("y")
+	if got := orig[1].String(); got != "by" {
+		t.Errorf("Clone is expected to be shallow, original = %q", got)
+	}
+	if got := CloneFunc([]int{}, func(v int) int { return v }); len(got) != 0 {
+		t.Errorf("CloneFunc([]) = %v", got)
+	}
+}
+
+func TestCloneDeep2D(t *testing.T) {
+	orig := [][]int{{1, 2}, nil, {3}}
+	c := CloneDeep2D(orig)
+	if !EqualFunc(c, orig, Equal[int]) {
+		t.Errorf("CloneDeep2D(%v) = %v", orig, c)
+	}
+	c[0][0] = 10
+	c[2] = append(c[2], 4)
+	if orig[0][0] != 1 || len(orig[2]) != 1 {
+		t.Errorf("mutating the CloneDeep2D copy changed the original: %v", orig)
+	}
+}
+
+func TestMinAndMax(t *testing.T) {
+	for _, tt := range minMaxTests {
+		if got := Min(tt.data); got != tt.wantMin {
+			t.Errorf("Min(%v) = %d, want %d", tt.data, got, tt.wantMin)
+		}
+		if got := Max(tt.data); got != tt.wantMax {
+			t.Errorf("Max(%v) = %d, want %d", tt.data, got, tt.wantMax)
+		}
+	}
+}
+
+func TestMinMaxStrings(t *testing.T) {
+	data := []string{"foo", "bar", "", "zoo"}
+	if got := Min(data); got != "" {
+		t.Errorf("Min(%q) = %q, want %q", data, got, "")
+	}
+	if got := Max(data); got != "zoo" {
+		t.Errorf("Max(%q) = %q, want %q", data, got, "zoo")
+	}
+}
+
+func TestMinAndMaxNaN(t *testing.T) {
+	for _, data := range [][]float64{
+		{math.NaN()},
+		{math.NaN(), 1, 2},
+		{1, math.NaN(), 2},
+		{1, 2, math.NaN()},
+	} {
+		if got := Min(data); !math.IsNaN(got) {
+			t.Errorf("Min(%v) = %v, want NaN", data, got)
+		}
+		if got := Max(data); !math.IsNaN(got) {
+			t.Errorf("Max(%v) = %v, want NaN", data, got)
+		}
+	}
+	data := []float64{math.Inf(1), -1.5, math.Inf(-1)}
+	if got := Min(data); !math.IsInf(got, -1) {
+		t.Errorf("Min(%v) = %v, want -Inf", data, got)
+	}
+	if got := Max(data); !math.IsInf(got, 1) {
+		t.Errorf("Max(%v) = %v, want +Inf", data, got)
+	}
+}
+
+func TestMinAndMaxPanics(t *testing.T) {
+	for name, f := range map[string]func([]int) int{
+		"Min": Min[int],
+		"Max": Max[int],
+	} {
+		func() {
+			defer func() {
+				if recover() == nil {
+					t.Errorf("%s(nil) did not panic", name)
+				}
+			}()
+			f(nil)
+		}()
+	}
+}
+
+func BenchmarkMin(b *testing.B) {
+	data := make([]int, 10000)
+	for i := range data {
+		data[i] = len(data) - i
+	}
+	for i := 0; i < b.N; i++ {
+		Min(data)
+	}
+}
+
+func BenchmarkMax(b *testing.B) {
+	data := make([]int, 10000)
+	for i := range data {
+		data[i] = i
+	}
+	for i := 0; i < b.N; i++ {
+		Max(data)
+	}
+}
+
+func BenchmarkMinFloat64(b *testing.B) {
+	data := make([]float64, 10000)
+	for i := range data {
+		data[i] = float64(len(data) - i)
+	}
+	for i := 0; i < b.N; i++ {
+		Min(data)
+	}
+}
+
+type account struct {
+	Name      string
+	CreatedAt int
+}
+
+func TestMinFuncAndMaxFunc(t *testing.T) {
+	data := []account{
+		{"b", 20},
+		{"first-oldest", 10},
+		{"c", 30},
+		{"second-oldest", 10},
+		{"first-newest", 40},
+		{"second-newest", 40},
+	}
+	less := func(a, b account) bool { return a.CreatedAt < b.CreatedAt }
+	if got := MinFunc(data, less); got.Name != "first-oldest" {
+		t.Errorf("MinFunc = %v, want first-oldest", got)
+	}
+	if got := MaxFunc(data, less); got.Name != "first-newest" {
+		t.Errorf("MaxFunc = %v, want first-newest", got)
+	}
+	single := data[:1]
+	if got := MinFunc(single, less); got != single[0] {
+		t.Errorf("MinFunc(%v) = %v, want %v", single, got, single[0])
+	}
+	if got := MaxFunc(single, less); got != single[0] {
+		t.Errorf("MaxFunc(%v) = %v, want %v", single, got, single[0])
+	}
+}
+
+func TestMinFuncAndMaxFuncPanics(t *testing.T) {
+	less := func(a, b int) bool { return a < b }
+	for name, f := range map[string]func([]int, func(a, b int) bool) int{
+		"MinFunc": MinFunc[int],
+		"MaxFunc": MaxFunc[int],
+	} {
+		func() {
+			defer func() {
+				if recover() == nil {
+					t.Errorf("%s(nil) did not panic", name)
+				}
+			}()
+			f(nil, less)
+		}()
+	}
+}
+
+func TestMinMax(t *testing.T) {
+	for _, tt := range minMaxTests {
+		gotMin, gotMax := MinMax(tt.data)
+		if gotMin != tt.wantMin || gotMax != tt.wantMax {
+			t.Errorf("MinMax(%v) = %d, %d, want %d, %d", tt.data, gotMin, gotMax, tt.wantMin, tt.wantMax)
+		}
+	}
+}
+
+func TestMinMaxRandom(t *testing.T) {
+	r := rand.New(rand.NewSource(1))
+	for n := 1; n < 50; n++ {
+		data := make([]int, n)
+		for i := range data {
+			data[i] = r.Intn(100) - 50
+		}
+		gotMin, gotMax := MinMax(data)
+		if wantMin, wantMax := Min(data), Max(data); gotMin != wantMin || gotMax != wantMax {
+			t.Errorf("MinMax(%v) = %d, %d, want %d, %d", data, gotMin, gotMax, wantMin, wantMax)
+		}
+	}
+}
+
+func TestMinMaxNaN(t *testing.T) {
+	for _, data := range [][]float64{
+		{math.NaN()},
+		{math.NaN(), 1, 2},
+		{1, math.NaN(), 2},
+		{1, 2, math.NaN()},
+		{1, 2, 0, math.NaN()},
+	} {
+		if gotMin, gotMax := MinMax(data); !math.IsNaN(gotMin) || !math.IsNaN(gotMax) {
+			t.Errorf("MinMax(%v) = %v, %v, want NaN, NaN", data, gotMin, gotMax)
+		}
+	}
+}
+
+func TestMinMaxFunc(t *testing.T) {
+	data := []account{
+		{"b", 20},
+		{"first-oldest", 10},
+		{"second-oldest", 10},
+		{"c", 30},
+		{"first-newest", 40},
+		{"second-newest", 40},
+		{"d", 25},
+	}
+	less := func(a, b account) bool { return a.CreatedAt < b.CreatedAt }
+	// Exercise every alignment of the tied elements within pairs.
+	for start := 0; start < 3; start++ {
+		s := data[start:]
+		gotMin, gotMax := MinMaxFunc(s, less)
+		if wantMin, wantMax := MinFunc(s, less), MaxFunc(s, less); gotMin != wantMin || gotMax != wantMax {
+			t.Errorf("MinMaxFunc(%v) = %v, %v, want %v, %v", s, gotMin, gotMax, wantMin, wantMax)
+		}
+	}
+}
+
+func TestMinMaxPanics(t *testing.T) {
+	defer func() {
+		if recover() == nil {
+			t.Errorf("MinMax(nil) did not panic")
+		}
+	}()
+	MinMax([]int(nil))
+}
+
+func BenchmarkMinMax(b *testing.B) {
+	r := rand.New(rand.NewSource(1))
+	data := make([]int, 100000)
+	for i := range data {
+		data[i] = r.Int()
+	}
+	b.Run("OnePass", func(b *testing.B) {
+		for i := 0; i < b.N; i++ {
+			MinMax(data)
+		}
+	})
+	b.Run("TwoPass", func(b *testing.B) {
+		for i := 0; i < b.N; i++ {
+			Min(data)
+			Max(data)
+		}
+	})
+}
+
+var argMinMaxTests = []struct {
+	data       []int
+	wantArgMin int
+	wantArgMax int
+}{
+	{nil, -1, -1},
+	{[]int{}, -1, -1},
+	{[]int{7}, 0, 0},
+	{[]int{1, 2}, 0, 1},
+	{[]int{2, 1}, 1, 0},
+	{[]int{3, 1, 4, 1, 5, 9, 2, 6, 9}, 1, 5},
+	{[]int{2, 2, 2}, 0, 0},
+	{[]int{5, 4, 3, 2, 1, 0}, 5, 0},
+	{[]int{0, 1, 2, 3, 4, 5}, 0, 5},
+}
+
+func TestArgMinArgMax(t *testing.T) {
+	less := func(a, b int) bool { return a < b }
+	for _, tt := range argMinMaxTests {
+		if got := ArgMin(tt.data); got != tt.wantArgMin {
+			t.Errorf("ArgMin(%v) = %d, want %d", tt.data, got, tt.wantArgMin)
+		}
+		if got := ArgMax(tt.data); got != tt.wantArgMax {
+			t.Errorf("ArgMax(%v) = %d, want %d", tt.data, got, tt.wantArgMax)
+		}
+		if got := ArgMinFunc(tt.data, less); got != tt.wantArgMin {
+			t.Errorf("ArgMinFunc(%v) = %d, want %d", tt.data, got, tt.wantArgMin)
+		}
+		if got := ArgMaxFunc(tt.data, less); got != tt.wantArgMax {
+			t.Errorf("ArgMaxFunc(%v) = %d, want %d", tt.data, got, tt.wantArgMax)
+		}
+	}
+}
+
+func TestArgMinArgMaxNaN(t *testing.T) {
+	data := []float64{3, math.NaN(), -1, math.NaN(), 7}
+	if got := ArgMin(data); got != 1 {
+		t.Errorf("ArgMin(%v) = %d, want 1", data, got)
+	}
+	if got := ArgMax(data); got != 1 {
+		t.Errorf("ArgMax(%v) = %d, want 1", data, got)
+	}
+}
+
+func TestReverse(t *testing.T) {
+	for _, tt := range []struct {
+		data []int
+		want []int
+	}{
+		{nil, nil},
+		{[]int{1}, []int{1}},
+		{[]int{1, 2}, []int{2, 1}},
+		{[]int{1, 2, 3}, []int{3, 2, 1}},
+		{[]int{1, 2, 3, 4}, []int{4, 3, 2, 1}},
+		{[]int{1, 2, 3, 4, 5}, []int{5, 4, 3, 2, 1}},
+	} {
+		s := Clone(tt.data)
+		Reverse(s)
+		if !Equal(s, tt.want) {
+			t.Errorf("Reverse(%v) = %v, want %v", tt.data, s, tt.want)
+		}
+	}
+}
+
+func TestReverseNamedSlice(t *testing.T) {
+	type names []string
+	s := names{"a", "b", "c"}
+	Reverse(s)
+	if want := (names{"c", "b", "a"}); !Equal(s, want) {
+		t.Errorf("Reverse = %v, want %v", s, want)
+	}
+}
+
+func TestReversed(t *testing.T) {
+	for _, tt := range []struct {
+		data []int
+		want []int
+	}{
+		{nil, []int{}},
+		{[]int{1}, []int{1}},
+		{[]int{1, 2}, []int{2, 1}},
+		{[]int{1, 2, 3}, []int{3, 2, 1}},
+	} {
+		orig := Clone(tt.data)
+		got := Reversed(tt.data)
+		if !Equal(got, tt.want) {
+			t.Errorf("Reversed(%v) = %v, want %v", tt.data, got, tt.want)
+		}
+		if !Equal(tt.data, orig) {
+			t.Errorf("Reversed modified its input: got %v, want %v", tt.data, orig)
+		}
+	}
+}
+
+func TestReversedDoesNotAlias(t *testing.T) {
+	type names []string
+	s := names{"newest", "middle", "oldest"}
+	r := Reversed(s)
+	r[0] = "changed"
+	if want := (names{"newest", "middle", "oldest"}); !Equal(s, want) {
+		t.Errorf("mutating result of Reversed changed the source: got %v, want %v", s, want)
+	}
+}
+
+func TestMap(t *testing.T) {
+	got := Map([]int{1, 2, 3}, strconv.Itoa)
+	if want := []string{"1", "2", "3"}; !Equal(got, want) {
+		t.Errorf("Map(strconv.Itoa) = %q, want %q", got, want)
+	}
+
+	users := []account{{"alice", 1}, {"bob", 2}}
+	names := Map(users, func(a account) string { return a.Name })
+	if want := []string{"alice", "bob"}; !Equal(names, want) {
+		t.Errorf("Map(Name) = %q, want %q", names, want)
+	}
+
+	if got := Map([]int(nil), strconv.Itoa); got != nil {
+		t.Errorf("Map(nil) = %#v, want nil", got)
+	}
+	if got := Map([]int{}, strconv.Itoa); got == nil || len(got) != 0 {
+		t.Errorf("Map([]int{}) = %#v, want empty non-nil slice", got)
+	}
+}
+
+func TestMapInPlace(t *testing.T) {
+	s := []int{1, 2, 3}
+	p := &s[0]
+	got := MapInPlace(s, func(v int) int { return v * 10 })
+	if !Equal(got, []int{10, 20, 30}) || !Equal(s, got) {
+		t.Errorf("MapInPlace(*10) = %v, s = %v, want [10 20 30]", got, s)
+	}
+	if &got[0] != p {
+		t.Errorf("MapInPlace returned a different backing array")
+	}
+	if got := MapInPlace(MapInPlace(s, func(v int) int { return v + 1 }), func(v int) int { return -v }); !Equal(got, []int{-11, -21, -31}) {
+		t.Errorf("chained MapInPlace = %v, want [-11 -21 -31]", got)
+	}
+	if got := MapInPlace([]int(nil), func(v int) int { return v }); got != nil {
+		t.Errorf("MapInPlace(nil) = %v, want nil", got)
+	}
+
+	data := make([]int, 100)
+	if n := testing.AllocsPerRun(100, func() { MapInPlace(data, func(v int) int { return v + 1 }) }); n != 0 {
+		t.Errorf("MapInPlace: got %v allocs, want 0", n)
+	}
+}
+
+func BenchmarkMap(b *testing.B) {
+	data := make([]int, 10000)
+	for i := range data {
+		data[i] = i
+	}
+	b.Run("Map", func(b *testing.B) {
+		b.ReportAllocs()
+		for i := 0; i < b.N; i++ {
+			Map(data, func(v int) int { return v * 2 })
+		}
+	})
+	b.Run("MapInPlace", func(b *testing.B) {
+		b.ReportAllocs()
+		for i := 0; i < b.N; i++ {
+			MapInPlace(data, func(v int) int { return v ^ 1 })
+		}
+	})
+	b.Run("Loop", func(b *testing.B) {
+		b.ReportAllocs()
+		for i := 0; i < b.N; i++ {
+			s := make([]int, len(data))
+			for j, v := range data {
+				s[j] = v * 2
+			}
+		}
+	})
+}
+
+func TestMapErr(t *testing.T) {
+	got, err := MapErr([]string{"1", "2", "3"}, strconv.Atoi)
+	if err != nil {
+		t.Fatalf("MapErr(Atoi) returned error: %v", err)
+	}
+	if want := []int{1, 2, 3}; !Equal(got, want) {
+		t.Errorf("MapErr(Atoi) = %v, want %v", got, want)
+	}
+
+	got, err = MapErr([]string(nil), strconv.Atoi)
+	if got != nil || err != nil {
+		t.Errorf("MapErr(nil) = %v, %v, want nil, nil", got, err)
+	}
+}
+
+func TestMapErrStopsAtFirstError(t *testing.T) {
+	var calls []string
+	got, err := MapErr([]string{"1", "x", "3", "y"}, func(s string) (int, error) {
+		calls = append(calls, s)
+		return strconv.Atoi(s)
+	})
+	if got != nil {
+		t.Errorf("MapErr returned %v on error, want nil", got)
+	}
+	if err == nil {
+		t.Fatal("MapErr returned nil error")
+	}
+	if !errors.Is(err, strconv.ErrSyntax) {
+		t.Errorf("MapErr error %v does not wrap strconv.ErrSyntax", err)
+	}
+	if !strings.HasPrefix(err.Error(), "index 1: ") {
+		t.Errorf("MapErr error %q does not report the failing index 1", err)
+	}
+	if want := []string{"1", "x"}; !Equal(calls, want) {
+		t.Errorf("MapErr called f with %q, want %q", calls, want)
+	}
+}
+
+func isEven(v int) bool { return v%2 == 0 }
+
+func TestFilter(t *testing.T) {
+	for _, tt := range []struct {
+		data []int
+		keep func(int) bool
+		want []int
+	}{
+		{nil, isEven, []int{}},
+		{[]int{1, 2, 3, 4, 5, 6}, isEven, []int{2, 4, 6}},
+		{[]int{1, 3, 5}, isEven, []int{}},
+		{[]int{2, 4, 6}, isEven, []int{2, 4, 6}},
+	} {
+		orig := Clone(tt.data)
+		got := Filter(tt.data, tt.keep)
+		if !Equal(got, tt.want) {
+			t.Errorf("Filter(%v) = %v, want %v", tt.data, got, tt.want)
+		}
+		if !Equal(tt.data, orig) {
+			t.Errorf("Filter modified its input: got %v, want %v", tt.data, orig)
+		}
+	}
+}
+
+func TestFilterNamedSlice(t *testing.T) {
+	type IDs []int
+	got := Filter(IDs{1, 2, 3, 4}, isEven)
+	if want := (IDs{2, 4}); !Equal(got, want) {
+		t.Errorf("Filter = %v, want %v", got, want)
+	}
+}
+
+// filterTwoPass is the count-then-copy alternative to the single append
+// loop used by Filter. It allocates exactly once but calls keep twice per
+// element, and benchmarks slower unless nearly every element is kept.
+func filterTwoPass[S constraints.Slice[T], T any](s S, keep func(T) bool) S {
+	n := 0
+	for _, v := range s {
+		if keep(v) {
+			n++
+		}
+	}
+	s2 := make(S, 0, n)
+	for _, v := range s {
+		if keep(v) {
+			s2 = append(s2, v)
+		}
+	}
+	return s2
+}
+
+func BenchmarkFilter(b *testing.B) {
+	data := make([]int, 10000)
+	for i := range data {
+		data[i] = i
+	}
+	for _, sel := range []struct {
+		name string
+		keep func(int) bool
+	}{
+		{"All", func(v int) bool { return true }},
+		{"Half", isEven},
+		{"Sparse", func(v int) bool { return v%100 == 0 }},
+	} {
+		b.Run(sel.name+"/Append", func(b *testing.B) {
+			b.ReportAllocs()
+			for i := 0; i < b.N; i++ {
+				Filter(data, sel.keep)
+			}
+		})
+		b.Run(sel.name+"/TwoPass", func(b *testing.B) {
+			b.ReportAllocs()
+			for i := 0; i < b.N; i++ {
+				filterTwoPass(data, sel.keep)
+			}
+		})
+	}
+}
+
+func TestFilterCallsKeepOnce(t *testing.T) {
+	calls := 0
+	Filter([]int{1, 2, 3, 4}, func(v int) bool {
+		calls++
+		return isEven(v)
+	})
+	if calls != 4 {
+		t.Errorf("Filter called keep %d times, want 4", calls)
+	}
+}
+
+func TestFilterInPlace(t *testing.T) {
+	for _, tt := range []struct {
+		data []int
+		want []int
+	}{
+		{nil, nil},
+		{[]int{1, 2, 3, 4, 5, 6}, []int{2, 4, 6}},
+		{[]int{1, 3, 5}, []int{}},
+		{[]int{2, 4, 6}, []int{2, 4, 6}},
+	} {
+		s := Clone(tt.data)
+		got := FilterInPlace(s, isEven)
+		if !Equal(got, tt.want) {
+			t.Errorf("FilterInPlace(%v) = %v, want %v", tt.data, got, tt.want)
+		}
+		if len(got) > 0 && &got[0] != &s[0] {
+			t.Errorf("FilterInPlace(%v) did not reuse the backing array", tt.data)
+		}
+	}
+}
+
+func TestFilterInPlaceZeroesTail(t *testing.T) {
+	s := make([]*int, 6)
+	for i := range s {
+		v := i
+		s[i] = &v
+	}
+	got := FilterInPlace(s, func(p *int) bool { return isEven(*p) })
+	if len(got) != 3 {
+		t.Fatalf("FilterInPlace returned %d elements, want 3", len(got))
+	}
+	for i, p := range got {
+		if *p != 2*i {
+			t.Errorf("got[%d] = %d, want %d", i, *p, 2*i)
+		}
+	}
+	for i, p := range got[len(got):len(s)] {
+		if p != nil {
+			t.Errorf("tail element %d = %v, want nil", len(got)+i, p)
+		}
+	}
+}
+
+func TestReduce(t *testing.T) {
+	sum := Reduce([]int{1, 2, 3, 4}, 0, func(acc, v int) int { return acc + v })
+	if sum != 10 {
+		t.Errorf("Reduce(sum) = %d, want 10", sum)
+	}
+
+	if got := Reduce([]int(nil), 42, func(acc, v int) int { return acc + v }); got != 42 {
+		t.Errorf("Reduce(nil) = %d, want init 42", got)
+	}
+
+	accounts := []account{{"alice", 1}, {"bob", 2}, {"carol", 3}}
+	byName := Reduce(accounts, map[string]int{}, func(acc map[string]int, a account) map[string]int {
+		acc[a.Name] = a.CreatedAt
+		return acc
+	})
+	if len(byName) != 3 || byName["alice"] != 1 || byName["bob"] != 2 || byName["carol"] != 3 {
+		t.Errorf("Reduce(map) = %v", byName)
+	}
+
+	// Subtraction is not commutative, so this pins down left-to-right order:
+	// ((100 - 1) - 2) - 3.
+	if got := Reduce([]int{1, 2, 3}, 100, func(acc, v int) int { return acc - v }); got != 94 {
+		t.Errorf("Reduce(subtract) = %d, want 94", got)
+	}
+	if got := Reduce([]string{"a", "b", "c"}, "", func(acc, v string) string { return acc + v }); got != "abc" {
+		t.Errorf("Reduce(concat) = %q, want %q", got, "abc")
+	}
+}
+
+func TestReduceRight(t *testing.T) {
+	s := []string{"a", "b", "c"}
+	got := ReduceRight(s, "", func(v, acc string) string { return acc + v })
+	if got != "cba" {
+		t.Errorf("ReduceRight(concat) = %q, want %q", got, "cba")
+	}
+	left := Reduce(s, "", func(acc, v string) string { return acc + v })
+	if left == got {
+		t.Errorf("Reduce and ReduceRight both returned %q", got)
+	}
+
+	// Build a right-associative expression: 1 - (2 - (3 - 0)).
+	if got := ReduceRight([]int{1, 2, 3}, 0, func(v, acc int) int { return v - acc }); got != 2 {
+		t.Errorf("ReduceRight(subtract) = %d, want 2", got)
+	}
+
+	if got := ReduceRight([]int(nil), "init", func(v int, acc string) string { return "x" }); got != "init" {
+		t.Errorf("ReduceRight(nil) = %q, want %q", got, "init")
+	}
+}
+
+type orderLine struct {
+	Item     string
+	Quantity int
+}
+
+func TestFlatMap(t *testing.T) {
+	lines := []orderLine{{"apple", 2}, {"pear", 0}, {"plum", 3}}
+	got := FlatMap(lines, func(l orderLine) []string {
+		items := make([]string, l.Quantity)
+		for i := range items {
+			items[i] = l.Item
+		}
+		return items
+	})
+	want := []string{"apple", "apple", "plum", "plum", "plum"}
+	if !Equal(got, want) {
+		t.Errorf("FlatMap = %q, want %q", got, want)
+	}
+
+	if got := FlatMap([]int{1, 2}, func(int) []int { return nil }); len(got) != 0 {
+		t.Errorf("FlatMap(empty results) = %v, want empty", got)
+	}
+	if got := FlatMap([]int(nil), func(v int) []int { return []int{v} }); len(got) != 0 {
+		t.Errorf("FlatMap(nil) = %v, want empty", got)
+	}
+}
+
+// flatMapAppend is the alternative to FlatMap that grows the output by
+// repeated appends. It is only faster when almost all inner slices hold a
+// single element.
+func flatMapAppend[T, U any](s []T, f func(T) []U) []U {
+	var s2 []U
+	for _, v := range s {
+		s2 = append(s2, f(v)...)
+	}
+	return s2
+}
+
+func BenchmarkFlatMap(b *testing.B) {
+	data := make([]int, 10000)
+	inner := []int{1, 2, 3, 4, 5, 6, 7, 8}
+	for _, k := range []int{1, 4, 8} {
+		f := func(int) []int { return inner[:k] }
+		b.Run(fmt.Sprintf("Inner%d/Presized", k), func(b *testing.B) {
+			b.ReportAllocs()
+			for i := 0; i < b.N; i++ {
+				FlatMap(data, f)
+			}
+		})
+		b.Run(fmt.Sprintf("Inner%d/Append", k), func(b *testing.B) {
+			b.ReportAllocs()
+			for i := 0; i < b.N; i++ {
+				flatMapAppend(data, f)
+			}
+		})
+	}
+}
+
+func TestFilterMap(t *testing.T) {
+	parse := func(s string) (int, bool) {
+		v, err := strconv.Atoi(s)
+		return v, err == nil
+	}
+	got := FilterMap([]string{"3", "x", "1", "", "2"}, parse)
+	if want := []int{3, 1, 2}; !Equal(got, want) {
+		t.Errorf("FilterMap = %v, want %v", got, want)
+	}
+	if got := FilterMap([]string{"a", "b"}, parse); len(got) != 0 {
+		t.Errorf("FilterMap(all dropped) = %v, want empty", got)
+	}
+	if got := FilterMap([]string(nil), parse); len(got) != 0 {
+		t.Errorf("FilterMap(nil) = %v, want empty", got)
+	}
+}
+
+func TestForEach(t *testing.T) {
+	var got []int
+	ForEach([]int{1, 2, 3}, func(v int) { got = append(got, v) })
+	if want := []int{1, 2, 3}; !Equal(got, want) {
+		t.Errorf("ForEach visited %v, want %v", got, want)
+	}
+	ForEach([]int(nil), func(v int) { t.Errorf("ForEach(nil) called f(%d)", v) })
+}
+
+func TestForEachIndexed(t *testing.T) {
+	var idx []int
+	var vals []string
+	s := []string{"a", "b", "c"}
+	ForEachIndexed(s, func(i int, v string) {
+		idx = append(idx, i)
+		vals = append(vals, v)
+	})
+	if want := []int{0, 1, 2}; !Equal(idx, want) {
+		t.Errorf("ForEachIndexed indices = %v, want %v", idx, want)
+	}
+	if !Equal(vals, s) {
+		t.Errorf("ForEachIndexed values = %v, want %v", vals, s)
+	}
+	if want := []string{"a", "b", "c"}; !Equal(s, want) {
+		t.Errorf("ForEachIndexed modified s: %v", s)
+	}
+}
+
+func TestForEachPtr(t *testing.T) {
+	s := []account{{"alice", 1}, {"bob", 2}}
+	ForEachPtr(s, func(a *account) { a.CreatedAt *= 10 })
+	if want := []account{{"alice", 10}, {"bob", 20}}; !Equal(s, want) {
+		t.Errorf("ForEachPtr = %v, want %v", s, want)
+	}
+}
+
+func TestLastIndex(t *testing.T) {
+	for _, tt := range []struct {
+		data []string
+		v    string
+		want int
+	}{
+		{nil, ";", -1},
+		{[]string{"a", ";", "b", ";", "c"}, ";", 3},
+		{[]string{";", "a", "b"}, ";", 0},
+		{[]string{"a", "b"}, ";", -1},
+		{[]string{";", ";"}, ";", 1},
+	} {
+		if got := LastIndex(tt.data, tt.v); got != tt.want {
+			t.Errorf("LastIndex(%q, %q) = %d, want %d", tt.data, tt.v, got, tt.want)
+		}
+		if got := LastIndexFunc(tt.data, func(s string) bool { return s == tt.v }); got != tt.want {
+			t.Errorf("LastIndexFunc(%q, == %q) = %d, want %d", tt.data, tt.v, got, tt.want)
+		}
+	}
+}
+
+type job struct {
+	ID     int
+	Status string
+}
+
+func TestContainsFunc(t *testing.T) {
+	jobs := []job{{1, "ok"}, {2, "failed"}, {3, "ok"}, {4, "failed"}}
+	calls := 0
+	failed := func(j job) bool {
+		calls++
+		return j.Status == "failed"
+	}
+	if !ContainsFunc(jobs, failed) {
+		t.Errorf("ContainsFunc(failed) = false, want true")
+	}
+	if calls != 2 {
+		t.Errorf("ContainsFunc called f %d times, want 2 (short-circuit at first match)", calls)
+	}
+
+	calls = 0
+	if ContainsFunc(jobs[:1], failed) {
+		t.Errorf("ContainsFunc(failed) on %v = true, want false", jobs[:1])
+	}
+	if calls != 1 {
+		t.Errorf("ContainsFunc called f %d times, want 1", calls)
+	}
+	if ContainsFunc([]job(nil), failed) {
+		t.Errorf("ContainsFunc(nil) = true, want false")
+	}
+}
+
+func TestContainsAllAny(t *testing.T) {
+	large := make([]int, 1000)
+	for i := range large {
+		large[i] = i * 2
+	}
+	largeOdd := make([]int, 1000)
+	for i := range largeOdd {
+		largeOdd[i] = i*2 + 1
+	}
+	for _, tt := range []struct {
+		name    string
+		s       []int
+		vs      []int
+		wantAll bool
+		wantAny bool
+	}{
+		{"empty vs", []int{1, 2}, nil, true, false},
+		{"empty s", nil, []int{1}, false, false},
+		{"both empty", nil, nil, true, false},
+		{"all present", []int{1, 2, 3}, []int{3, 1}, true, true},
+		{"some present", []int{1, 2, 3}, []int{3, 4}, false, true},
+		{"none present", []int{1, 2, 3}, []int{4, 5}, false, false},
+		{"duplicates in vs", []int{1, 2, 3}, []int{2, 2, 2}, true, true},
+		{"large all present", large, large[100:200], true, true},
+		{"large vs all present", large[:100], append(Clone(large[:100]), large[:100]...), true, true},
+		{"large disjoint", large, largeOdd, false, false},
+		{"large one present", large, append(Clone(largeOdd), 10), false, true},
+		{"large s small vs", large, append(Clone(largeOdd[:100]), large[0]), false, true},
+		{"large vs small s", large[:100], largeOdd, false, false},
+		{"large s all of vs at the end", large, append(Clone(large[990:]), large[990:]...), true, true},
+	} {
+		if got := ContainsAll(tt.s, tt.vs...); got != tt.wantAll {
+			t.Errorf("%s: ContainsAll = %t, want %t", tt.name, got, tt.wantAll)
+		}
+		if got := ContainsAny(tt.s, tt.vs...); got != tt.wantAny {
+			t.Errorf("%s: ContainsAny = %t, want %t", tt.name, got, tt.wantAny)
+		}
+	}
+}
+
+func containsAllLinear[T comparable](s []T, vs ...T) bool {
+	for _, v := range vs {
+		if !Contains(s, v) {
+			return false
+		}
+	}
+	return true
+}
+
+func containsAllSet[T comparable](s []T, vs ...T) bool {
+	set := ToSet(s)
+	for _, v := range vs {
+		if _, ok := set[v]; !ok {
+			return false
+		}
+	}
+	return true
+}
+
+// BenchmarkContainsAll compares linear scanning with building a set for
+// inputs of various sizes; the crossover determines setThreshold.
+func BenchmarkContainsAll(b *testing.B) {
+	for _, n := range []int{8, 16, 32, 64, 128, 256} {
+		s := make([]int, n)
+		vs := make([]int, n)
+		for i := range s {
+			s[i] = i
+			vs[i] = n - 1 - i
+		}
+		b.Run(fmt.Sprintf("%dx%d/Linear", n, n), func(b *testing.B) {
+			for i := 0; i < b.N; i++ {
+				containsAllLinear(s, vs...)
+			}
+		})
+		b.Run(fmt.Sprintf("%dx%d/Set", n, n), func(b *testing.B) {
+			for i := 0; i < b.N; i++ {
+				containsAllSet(s, vs...)
+			}
+		})
+	}
+}
+
+func TestCount(t *testing.T) {
+	statuses := []string{"ok", "failed", "ok", "ok", "pending"}
+	for _, tt := range []struct {
+		s    []string
+		v    string
+		want int
+	}{
+		{nil, "ok", 0},
+		{statuses, "ok", 3},
+		{statuses, "failed", 1},
+		{statuses, "unknown", 0},
+		{[]string{"ok", "ok"}, "ok", 2},
+	} {
+		if got := Count(tt.s, tt.v); got != tt.want {
+			t.Errorf("Count(%q, %q) = %d, want %d", tt.s, tt.v, got, tt.want)
+		}
+	}
+}
+
+func TestCountFunc(t *testing.T) {
+	data := []int{1, 2, 3, 4, 5}
+	if got := CountFunc(data, isEven); got != 2 {
+		t.Errorf("CountFunc(isEven) = %d, want 2", got)
+	}
+	if got := CountFunc(data, func(int) bool { return false }); got != 0 {
+		t.Errorf("CountFunc(false) = %d, want 0", got)
+	}
+	if got := CountFunc(data, func(int) bool { return true }); got != len(data) {
+		t.Errorf("CountFunc(true) = %d, want %d", got, len(data))
+	}
+
+	var seen []int
+	CountFunc(data, func(v int) bool {
+		seen = append(seen, v)
+		return true
+	})
+	if !Equal(seen, data) {
+		t.Errorf("CountFunc evaluated f on %v, want each of %v exactly once", seen, data)
+	}
+}
+
+func TestFind(t *testing.T) {
+	jobs := []*job{{1, "ok"}, {2, "failed"}, {3, "ok"}, {4, "failed"}}
+	failed := func(j *job) bool { return j.Status == "failed" }
+
+	if got, ok := Find(jobs, failed); !ok || got != jobs[1] {
+		t.Errorf("Find(failed) = %v, %t, want %v, true", got, ok, jobs[1])
+	}
+	if got, ok := FindLast(jobs, failed); !ok || got != jobs[3] {
+		t.Errorf("FindLast(failed) = %v, %t, want %v, true", got, ok, jobs[3])
+	}
+
+	pending := func(j *job) bool { return j.Status == "pending" }
+	if got, ok := Find(jobs, pending); ok || got != nil {
+		t.Errorf("Find(pending) = %v, %t, want nil, false", got, ok)
+	}
+	if got, ok := FindLast(jobs, pending); ok || got != nil {
+		t.Errorf("FindLast(pending) = %v, %t, want nil, false", got, ok)
+	}
+	if got, ok := Find([]*job(nil), failed); ok || got != nil {
+		t.Errorf("Find(nil) = %v, %t, want nil, false", got, ok)
+	}
+}
+
+func TestGetAt(t *testing.T) {
+	s := []int{10, 20, 30}
+	for _, tt := range []struct {
+		i               int
+		wantGet, wantAt int
+		okGet, okAt     bool
+	}{
+		{0, 10, 10, true, true},
+		{2, 30, 30, true, true},
+		{3, 0, 0, false, false},
+		{-1, 0, 30, false, true},
+		{-3, 0, 10, false, true},
+		{-4, 0, 0, false, false},
+	} {
+		if got, ok := Get(s, tt.i); got != tt.wantGet || ok != tt.okGet {
+			t.Errorf("Get(%v, %d) = %d, %t, want %d, %t", s, tt.i, got, ok, tt.wantGet, tt.okGet)
+		}
+		if got, ok := At(s, tt.i); got != tt.wantAt || ok != tt.okAt {
+			t.Errorf("At(%v, %d) = %d, %t, want %d, %t", s, tt.i, got, ok, tt.wantAt, tt.okAt)
+		}
+	}
+	for _, i := range []int{-1, 0, 1} {
+		if _, ok := At([]int{}, i); ok {
+			t.Errorf("At([], %d) reported ok", i)
+		}
+		if _, ok := Get([]int(nil), i); ok {
+			t.Errorf("Get(nil, %d) reported ok", i)
+		}
+	}
+}
+
+func TestFirstLast(t *testing.T) {
+	jobs := []*job{{1, "ok"}, {2, "failed"}}
+	if got, ok := First(jobs); !ok || got != jobs[0] {
+		t.Errorf("First(jobs) = %v, %t, want %v, true", got, ok, jobs[0])
+	}
+	if got, ok := Last(jobs); !ok || got != jobs[1] {
+		t.Errorf("Last(jobs) = %v, %t, want %v, true", got, ok, jobs[1])
+	}
+	if got, ok := First([]*job(nil)); ok || got != nil {
+		t.Errorf("First(nil) = %v, %t, want nil, false", got, ok)
+	}
+	if got, ok := Last([]*job{}); ok || got != nil {
+		t.Errorf("Last([]) = %v, %t, want nil, false", got, ok)
+	}
+
+	fallback := &job{0, "none"}
+	if got := FirstOr(jobs, fallback); got != jobs[0] {
+		t.Errorf("FirstOr(jobs) = %v, want %v", got, jobs[0])
+	}
+	if got := LastOr(jobs, fallback); got != jobs[1] {
+		t.Errorf("LastOr(jobs) = %v, want %v", got, jobs[1])
+	}
+	if got := FirstOr(nil, fallback); got != fallback {
+		t.Errorf("FirstOr(nil) = %v, want fallback", got)
+	}
+	if got := LastOr([]*job{}, fallback); got != fallback {
+		t.Errorf("LastOr([]) = %v, want fallback", got)
+	}
+}
+
+func TestIndexFrom(t *testing.T) {
+	data := []int{1, 2, 1, 3, 1}
+	for _, tt := range []struct {
+		v     int
+		start int
+		want  int
+	}{
+		{1, 0, 0},
+		{1, 1, 2},
+		{1, 2, 2},
+		{1, 3, 4},
+		{1, 5, -1},
+		{1, 100, -1},
+		{1, -1, 0},
+		{1, -100, 0},
+		{3, 4, -1},
+		{4, 0, -1},
+	} {
+		if got := IndexFrom(data, tt.v, tt.start); got != tt.want {
+			t.Errorf("IndexFrom(%v, %d, %d) = %d, want %d", data, tt.v, tt.start, got, tt.want)
+		}
+		eq := func(e int) bool { return e == tt.v }
+		if got := IndexFuncFrom(data, eq, tt.start); got != tt.want {
+			t.Errorf("IndexFuncFrom(%v, == %d, %d) = %d, want %d", data, tt.v, tt.start, got, tt.want)
+		}
+	}
+	if got := IndexFrom([]int(nil), 1, 0); got != -1 {
+		t.Errorf("IndexFrom(nil) = %d, want -1", got)
+	}
+}
+
+func TestIndexFromAllOccurrences(t *testing.T) {
+	data := []string{"{}", "a", "{}", "{}", "b"}
+	var got []int
+	for i := IndexFrom(data, "{}", 0); i >= 0; i = IndexFrom(data, "{}", i+1) {
+		got = append(got, i)
+	}
+	if want := []int{0, 2, 3}; !Equal(got, want) {
+		t.Errorf("occurrences = %v, want %v", got, want)
+	}
+}
+
+func TestIndexAll(t *testing.T) {
+	for _, tt := range []struct {
+		data []string
+		v    string
+		want []int
+	}{
+		{nil, "{}", nil},
+		{[]string{"a", "b"}, "{}", nil},
+		{[]string{"{}", "a", "{}", "{}", "b", "{}"}, "{}", []int{0, 2, 3, 5}},
+		{[]string{"{}", "{}", "{}"}, "{}", []int{0, 1, 2}},
+	} {
+		got := IndexAll(tt.data, tt.v)
+		if !Equal(got, tt.want) || (got == nil) != (tt.want == nil) {
+			t.Errorf("IndexAll(%q, %q) = %#v, want %#v", tt.data, tt.v, got, tt.want)
+		}
+		got = IndexAllFunc(tt.data, func(s string) bool { return s == tt.v })
+		if !Equal(got, tt.want) || (got == nil) != (tt.want == nil) {
+			t.Errorf("IndexAllFunc(%q, == %q) = %#v, want %#v", tt.data, tt.v, got, tt.want)
+		}
+	}
+}
+
+func TestChunk(t *testing.T) {
+	for _, tt := range []struct {
+		data []int
+		size int
+		want [][]int
+	}{
+		{nil, 2, [][]int{}},
+		{[]int{1}, 2, [][]int{{1}}},
+		{[]int{1, 2, 3, 4}, 2, [][]int{{1, 2}, {3, 4}}},
+		{[]int{1, 2, 3, 4, 5}, 2, [][]int{{1, 2}, {3, 4}, {5}}},
+		{[]int{1, 2, 3}, 1, [][]int{{1}, {2}, {3}}},
+		{[]int{1, 2, 3}, 5, [][]int{{1, 2, 3}}},
+	} {
+		got := Chunk(tt.data, tt.size)
+		if !EqualFunc(got, tt.want, Equal[int]) {
+			t.Errorf("Chunk(%v, %d) = %v, want %v", tt.data, tt.size, got, tt.want)
+		}
+	}
+}
+
+func TestChunkAliasing(t *testing.T) {
+	data := []int{1, 2, 3, 4, 5}
+	chunks := Chunk(data, 2)
+	chunks[1][0] = 30
+	if data[2] != 30 {
+		t.Errorf("Chunk did not alias the input: data = %v", data)
+	}
+	chunks[0] = append(chunks[0], 99)
+	if data[2] != 30 {
+		t.Errorf("appending to a chunk overwrote the next one: data = %v", data)
+	}
+}
+
+func TestChunkPanics(t *testing.T) {
+	for _, size := range []int{0, -1} {
+		func() {
+			defer func() {
+				if recover() == nil {
+					t.Errorf("Chunk(_, %d) did not panic", size)
+				}
+			}()
+			Chunk([]int{1, 2}, size)
+		}()
+	}
+}
+
+func TestChunkBy(t *testing.T) {
+	type visit struct {
+		User string
+		At   int
+	}
+	visits := []visit{{"a", 1}, {"a", 2}, {"b", 3}, {"a", 4}, {"c", 5}, {"c", 6}}
+	user := func(v visit) string { return v.User }
+	got := ChunkBy(visits, user)
+	want := [][]visit{
+		{{"a", 1}, {"a", 2}},
+		{{"b", 3}},
+		{{"a", 4}},
+		{{"c", 5}, {"c", 6}},
+	}
+	if !EqualFunc(got, want, Equal[visit]) {
+		t.Errorf("ChunkBy(visits, user) = %v, want %v", got, want)
+	}
+	if groups := GroupBy(visits, user); len(groups["a"]) != 3 {
+		t.Errorf("GroupBy merged %d visits of a, want 3", len(groups["a"]))
+	}
+
+	calls := 0
+	ChunkBy(visits, func(v visit) string { calls++; return v.User })
+	if calls != len(visits) {
+		t.Errorf("ChunkBy called key %d times, want %d", calls, len(visits))
+	}
+
+	if got := ChunkBy([]int{}, func(v int) int { return v }); got == nil || len(got) != 0 {
+		t.Errorf("ChunkBy([]) = %#v, want empty non-nil", got)
+	}
+
+	s := []int{1, 1, 2}
+	chunks := ChunkBy(s, func(v int) int { return v })
+	chunks[0][1] = 10
+	chunks[0] = append(chunks[0], 20)
+	if !Equal(s, []int{1, 10, 2}) {
+		t.Errorf("ChunkBy chunks do not alias s correctly: %v", s)
+	}
+}
+
+func TestWindow(t *testing.T) {
+	data := []int{1, 2, 3, 4}
+	for _, tt := range []struct {
+		size int
+		want [][]int
+	}{
+		{1, [][]int{{1}, {2}, {3}, {4}}},
+		{2, [][]int{{1, 2}, {2, 3}, {3, 4}}},
+		{4, [][]int{{1, 2, 3, 4}}},
+		{5, [][]int{}},
+	} {
+		got := Window(data, tt.size)
+		if !EqualFunc(got, tt.want, Equal[int]) {
+			t.Errorf("Window(%v, %d) = %v, want %v", data, tt.size, got, tt.want)
+		}
+	}
+	if got := Window([]int(nil), 1); len(got) != 0 {
+		t.Errorf("Window(nil, 1) = %v, want empty", got)
+	}
+
+	windows := Window(data, 2)
+	windows[1][0] = 20
+	if data[1] != 20 {
+		t.Errorf("Window did not alias the input: data = %v", data)
+	}
+}
+
+func TestWindowStep(t *testing.T) {
+	data := []int{1, 2, 3, 4, 5, 6, 7}
+	for _, tt := range []struct {
+		size, step int
+		want       [][]int
+	}{
+		{2, 1, [][]int{{1, 2}, {2, 3}, {3, 4}, {4, 5}, {5, 6}, {6, 7}}},
+		{2, 2, [][]int{{1, 2}, {3, 4}, {5, 6}}},
+		{2, 3, [][]int{{1, 2}, {4, 5}}},
+		{1, 3, [][]int{{1}, {4}, {7}}},
+		{3, 10, [][]int{{1, 2, 3}}},
+		{7, 2, [][]int{{1, 2, 3, 4, 5, 6, 7}}},
+	} {
+		got := WindowStep(data, tt.size, tt.step)
+		if !EqualFunc(got, tt.want, Equal[int]) {
+			t.Errorf("WindowStep(%v, %d, %d) = %v, want %v", data, tt.size, tt.step, got, tt.want)
+		}
+	}
+}
+
+func TestWindowStepPanics(t *testing.T) {
+	for _, tt := range []struct{ size, step int }{{0, 1}, {1, 0}, {-1, 1}, {1, -1}} {
+		func() {
+			defer func() {
+				if recover() == nil {
+					t.Errorf("WindowStep(_, %d, %d) did not panic", tt.size, tt.step)
+				}
+			}()
+			WindowStep([]int{1, 2}, tt.size, tt.step)
+		}()
+	}
+}
+
+func TestFlatten(t *testing.T) {
+	for _, tt := range []struct {
+		ss   [][]int
+		want []int
+	}{
+		{nil, []int{}},
+		{[][]int{nil, nil}, []int{}},
+		{[][]int{{1, 2}, nil, {3}, {}, {4, 5}}, []int{1, 2, 3, 4, 5}},
+	} {
+		got := Flatten(tt.ss)
+		if !Equal(got, tt.want) {
+			t.Errorf("Flatten(%v) = %v, want %v", tt.ss, got, tt.want)
+		}
+		if cap(got) != len(got) {
+			t.Errorf("Flatten(%v) has cap %d, want %d", tt.ss, cap(got), len(got))
+		}
+	}
+}
+
+func BenchmarkFlatten(b *testing.B) {
+	ss := make([][]int, 10000)
+	for i := range ss {
+		ss[i] = []int{i, i + 1, i + 2}
+	}
+	b.Run("Presized", func(b *testing.B) {
+		b.ReportAllocs()
+		for i := 0; i < b.N; i++ {
+			Flatten(ss)
+		}
+	})
+	b.Run("Append", func(b *testing.B) {
+		b.ReportAllocs()
+		for i := 0; i < b.N; i++ {
+			var s []int
+			for _, inner := range ss {
+				s = append(s, inner...)
+			}
+		}
+	})
+}
+
+func TestConcat(t *testing.T) {
+	type IDs []int
+	a, b, c := IDs{1, 2}, IDs{}, IDs{3, 4, 5}
+	got := Concat(a, b, c)
+	if want := (IDs{1, 2, 3, 4, 5}); !Equal(got, want) {
+		t.Errorf("Concat = %v, want %v", got, want)
+	}
+
+	if got := Concat[IDs](); got == nil || len(got) != 0 {
+		t.Errorf("Concat() = %#v, want empty non-nil slice", got)
+	}
+
+	single := Concat(a)
+	single[0] = 100
+	if a[0] != 1 {
+		t.Errorf("Concat(a) aliases a: a = %v", a)
+	}
+	got[0] = 100
+	if a[0] != 1 {
+		t.Errorf("Concat(a, b, c) aliases a: a = %v", a)
+	}
+}
+
+func TestRepeat(t *testing.T) {
+	if got := Repeat("a", 3); !Equal(got, []string{"a", "a", "a"}) {
+		t.Errorf("Repeat(a, 3) = %q", got)
+	}
+	if got := Repeat("a", 0); got == nil || len(got) != 0 {
+		t.Errorf("Repeat(a, 0) = %#v, want empty non-nil slice", got)
+	}
+}
+
+func TestRepeatSlice(t *testing.T) {
+	type IDs []int
+	for _, tt := range []struct {
+		s    IDs
+		n    int
+		want IDs
+	}{
+		{IDs{1, 2}, 0, IDs{}},
+		{IDs{1, 2}, 1, IDs{1, 2}},
+		{IDs{1, 2}, 3, IDs{1, 2, 1, 2, 1, 2}},
+		{IDs{1, 2, 3}, 5, IDs{1, 2, 3, 1, 2, 3, 1, 2, 3, 1, 2, 3, 1, 2, 3}},
+		{nil, 10, IDs{}},
+		{nil, math.MaxInt, IDs{}},
+	} {
+		got := RepeatSlice(tt.s, tt.n)
+		if !Equal(got, tt.want) {
+			t.Errorf("RepeatSlice(%v, %d) = %v, want %v", tt.s, tt.n, got, tt.want)
+		}
+	}
+
+	s := []int{1}
+	got := RepeatSlice(s, 2)
+	got[0] = 100
+	if s[0] != 1 {
+		t.Errorf("RepeatSlice aliases its input")
+	}
+}
+
+func TestRepeatPanics(t *testing.T) {
+	for _, tt := range []struct {
+		name string
+		f    func()
+	}{
+		{"Repeat negative", func() { Repeat(1, -1) }},
+		{"RepeatSlice negative", func() { RepeatSlice([]int{1}, -1) }},
+		{"RepeatSlice overflow", func() { RepeatSlice([]int{1, 2}, math.MaxInt/2+1) }},
+		{"RepeatSlice overflow large", func() { RepeatSlice(make([]byte, 1<<20), math.MaxInt) }},
+	} {
+		func() {
+			defer func() {
+				if recover() == nil {
+					t.Errorf("%s: did not panic", tt.name)
+				}
+			}()
+			tt.f()
+		}()
+	}
+}
+
+func TestFill(t *testing.T) {
+	s := []account{{"a", 1}, {"b", 2}, {"c", 3}}
+	Fill(s, account{})
+	if want := make([]account, 3); !Equal(s, want) {
+		t.Errorf("Fill = %v, want %v", s, want)
+	}
+	Fill([]int(nil), 1) // must not panic
+}
+
+func TestFillRange(t *testing.T) {
+	for _, tt := range []struct {
+		i, j int
+		want []int
+	}{
+		{0, 5, []int{9, 9, 9, 9, 9}},
+		{1, 3, []int{0, 9, 9, 3, 4}},
+		{2, 2, []int{0, 1, 2, 3, 4}},
+		{5, 5, []int{0, 1, 2, 3, 4}},
+	} {
+		s := []int{0, 1, 2, 3, 4}
+		FillRange(s, 9, tt.i, tt.j)
+		if !Equal(s, tt.want) {
+			t.Errorf("FillRange(_, 9, %d, %d) = %v, want %v", tt.i, tt.j, s, tt.want)
+		}
+	}
+}
+
+func TestFillRangePanics(t *testing.T) {
+	for _, tt := range []struct{ i, j int }{{-1, 2}, {3, 2}, {0, 6}, {6, 6}} {
+		func() {
+			defer func() {
+				if recover() == nil {
+					t.Errorf("FillRange(_, 0, %d, %d) did not panic", tt.i, tt.j)
+				}
+			}()
+			s := make([]int, 5, 10)
+			FillRange(s, 0, tt.i, tt.j)
+		}()
+	}
+}
+
+func TestZip(t *testing.T) {
+	for _, tt := range []struct {
+		a    []int
+		b    []string
+		want []Pair[int, string]
+	}{
+		{nil, nil, []Pair[int, string]{}},
+		{[]int{1, 2}, nil, []Pair[int, string]{}},
+		{nil, []string{"a"}, []Pair[int, string]{}},
+		{[]int{1, 2}, []string{"a", "b"}, []Pair[int, string]{{1, "a"}, {2, "b"}}},
+		{[]int{1, 2, 3}, []string{"a"}, []Pair[int, string]{{1, "a"}}},
+		{[]int{1}, []string{"a", "b", "c"}, []Pair[int, string]{{1, "a"}}},
+	} {
+		got := Zip(tt.a, tt.b)
+		if !Equal(got, tt.want) {
+			t.Errorf("Zip(%v, %q) = %v, want %v", tt.a, tt.b, got, tt.want)
+		}
+	}
+}
+
+func TestZipLongest(t *testing.T) {
+	for _, tt := range []struct {
+		a    []int
+		b    []string
+		want []Pair[int, string]
+	}{
+		{nil, nil, []Pair[int, string]{}},
+		{nil, []string{"a", "b"}, []Pair[int, string]{{-1, "a"}, {-1, "b"}}},
+		{[]int{1, 2}, nil, []Pair[int, string]{{1, "?"}, {2, "?"}}},
+		{[]int{1, 2, 3}, []string{"a"}, []Pair[int, string]{{1, "a"}, {2, "?"}, {3, "?"}}},
+		{[]int{1}, []string{"a", "b"}, []Pair[int, string]{{1, "a"}, {-1, "b"}}},
+	} {
+		got := ZipLongest(tt.a, tt.b, -1, "?")
+		if !Equal(got, tt.want) {
+			t.Errorf("ZipLongest(%v, %q) = %v, want %v", tt.a, tt.b, got, tt.want)
+		}
+	}
+
+	a, b := []int{1, 2, 3}, []string{"a", "b", "c"}
+	if got, want := ZipLongest(a, b, -1, "?"), Zip(a, b); !Equal(got, want) {
+		t.Errorf("ZipLongest of equal lengths = %v, want Zip result %v", got, want)
+	}
+}
+
+func TestZipWith(t *testing.T) {
+	add := func(x, y float64) float64 { return x + y }
+	for _, tt := range []struct {
+		a, b []float64
+		want []float64
+	}{
+		{nil, nil, []float64{}},
+		{[]float64{1, 2}, nil, []float64{}},
+		{[]float64{1, 2, 3}, []float64{0.5, 0.25, 0.125}, []float64{1.5, 2.25, 3.125}},
+		{[]float64{1, 2, 3}, []float64{10}, []float64{11}},
+		{[]float64{1}, []float64{10, 20}, []float64{11}},
+	} {
+		calls := 0
+		got := ZipWith(tt.a, tt.b, func(x, y float64) float64 {
+			calls++
+			return add(x, y)
+		})
+		if !Equal(got, tt.want) {
+			t.Errorf("ZipWith(%v, %v, +) = %v, want %v", tt.a, tt.b, got, tt.want)
+		}
+		if calls != len(tt.want) {
+			t.Errorf("ZipWith(%v, %v, +) called f %d times, want %d", tt.a, tt.b, calls, len(tt.want))
+		}
+	}
+}
+
+func TestPairwise(t *testing.T) {
+	for _, tt := range []struct {
+		s    []int
+		want []Pair[int, int]
+	}{
+		{nil, []Pair[int, int]{}},
+		{[]int{1}, []Pair[int, int]{}},
+		{[]int{1, 2}, []Pair[int, int]{{1, 2}}},
+		{[]int{1, 2, 3}, []Pair[int, int]{{1, 2}, {2, 3}}},
+	} {
+		got := Pairwise(tt.s)
+		if !Equal(got, tt.want) || got == nil {
+			t.Errorf("Pairwise(%v) = %#v, want %v", tt.s, got, tt.want)
+		}
+	}
+}
+
+func TestPairwiseFunc(t *testing.T) {
+	timestamps := []int64{1000, 1250, 1300, 2300}
+	deltas := PairwiseFunc(timestamps, func(prev, cur int64) int64 { return cur - prev })
+	if want := []int64{250, 50, 1000}; !Equal(deltas, want) {
+		t.Errorf("PairwiseFunc(%v, delta) = %v, want %v", timestamps, deltas, want)
+	}
+	calls := 0
+	got := PairwiseFunc([]int64{1}, func(prev, cur int64) int64 { calls++; return 0 })
+	if len(got) != 0 || got == nil || calls != 0 {
+		t.Errorf("PairwiseFunc of one element = %#v after %d calls", got, calls)
+	}
+}
+
+func TestUnzip(t *testing.T) {
+	a, b := []int{1, 2, 3}, []string{"a", "b", "c"}
+	gotA, gotB := Unzip(Zip(a, b))
+	if !Equal(gotA, a) || !Equal(gotB, b) {
+		t.Errorf("Unzip(Zip(%v, %q)) = %v, %q", a, b, gotA, gotB)
+	}
+
+	gotA, gotB = Unzip([]Pair[int, string](nil))
+	if gotA == nil || len(gotA) != 0 || gotB == nil || len(gotB) != 0 {
+		t.Errorf("Unzip(nil) = %#v, %#v, want two empty slices", gotA, gotB)
+	}
+}
+
+type logLine struct {
+	Severity string
+	Message  string
+}
+
+func TestGroupBy(t *testing.T) {
+	lines := []logLine{
+		{"info", "starting"},
+		{"error", "disk full"},
+		{"info", "retrying"},
+		{"warn", "slow"},
+		{"error", "giving up"},
+	}
+	severity := func(l logLine) string { return l.Severity }
+
+	got := GroupBy(lines, severity)
+	want := map[string][]logLine{
+		"info":  {lines[0], lines[2]},
+		"error": {lines[1], lines[4]},
+		"warn":  {lines[3]},
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("GroupBy = %v, want %v", got, want)
+	}
+
+	same := GroupBy(lines, func(logLine) int { return 0 })
+	if len(same) != 1 || !Equal(same[0], lines) {
+		t.Errorf("GroupBy(constant key) = %v", same)
+	}
+
+	distinct := GroupBy(lines, func(l logLine) string { return l.Message })
+	if len(distinct) != len(lines) {
+		t.Errorf("GroupBy(distinct keys) has %d groups, want %d", len(distinct), len(lines))
+	}
+
+	if empty := GroupBy([]logLine(nil), severity); empty == nil || len(empty) != 0 {
+		t.Errorf("GroupBy(nil) = %#v, want empty non-nil map", empty)
+	}
+}
+
+func TestPartition(t *testing.T) {
+	for _, tt := range []struct {
+		data    []int
+		wantYes []int
+		wantNo  []int
+	}{
+		{nil, nil, nil},
+		{[]int{1, 2, 3, 4, 5}, []int{2, 4}, []int{1, 3, 5}},
+		{[]int{2, 4}, []int{2, 4}, nil},
+		{[]int{1, 3}, nil, []int{1, 3}},
+	} {
+		yes, no := Partition(tt.data, isEven)
+		if !Equal(yes, tt.wantYes) || !Equal(no, tt.wantNo) {
+			t.Errorf("Partition(%v) = %v, %v, want %v, %v", tt.data, yes, no, tt.wantYes, tt.wantNo)
+		}
+		if len(yes)+len(no) != len(tt.data) {
+			t.Errorf("Partition(%v) lengths sum to %d, want %d", tt.data, len(yes)+len(no), len(tt.data))
+		}
+	}
+}
+
+func TestUnique(t *testing.T) {
+	for _, tt := range []struct {
+		data        []int
+		want        []int
+		wantCompact []int
+	}{
+		{nil, []int{}, nil},
+		{[]int{1, 2, 3}, []int{1, 2, 3}, []int{1, 2, 3}},
+		{[]int{1, 1, 2, 2}, []int{1, 2}, []int{1, 2}},
+		{[]int{3, 1, 3, 2, 1, 3}, []int{3, 1, 2}, []int{3, 1, 3, 2, 1, 3}},
+		{[]int{1, 2, 1, 1, 2}, []int{1, 2}, []int{1, 2, 1, 2}},
+	} {
+		orig := Clone(tt.data)
+		got := Unique(tt.data)
+		if !Equal(got, tt.want) {
+			t.Errorf("Unique(%v) = %v, want %v", tt.data, got, tt.want)
+		}
+		if !Equal(tt.data, orig) {
+			t.Errorf("Unique modified its input: got %v, want %v", tt.data, orig)
+		}
+		// Compact only removes adjacent duplicates.
+		if got := Compact(Clone(tt.data)); !Equal(got, tt.wantCompact) {
+			t.Errorf("Compact(%v) = %v, want %v", tt.data, got, tt.wantCompact)
+		}
+	}
+}
+
+func BenchmarkUnique(b *testing.B) {
+	r := rand.New(rand.NewSource(1))
+	data := make([]int, 100000)
+	for i := range data {
+		data[i] = r.Intn(100) // high duplicate ratio
+	}
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		Unique(data)
+	}
+}
+
+func TestUniqueBy(t *testing.T) {
+	type tagged struct {
+		ID   int
+		Tags []string // makes tagged non-comparable
+	}
+	data := []tagged{
+		{1, []string{"first"}},
+		{2, []string{"first"}},
+		{1, []string{"second"}},
+		{3, nil},
+		{2, []string{"second"}},
+	}
+	got := UniqueBy(data, func(v tagged) int { return v.ID })
+	if len(got) != 3 {
+		t.Fatalf("UniqueBy returned %d elements, want 3", len(got))
+	}
+	for i, wantID := range []int{1, 2, 3} {
+		if got[i].ID != wantID {
+			t.Errorf("got[%d].ID = %d, want %d", i, got[i].ID, wantID)
+		}
+		if got[i].ID != 3 && got[i].Tags[0] != "first" {
+			t.Errorf("got[%d] = %v, want the first element with ID %d", i, got[i], wantID)
+		}
+	}
+	if got := UniqueBy([]tagged(nil), func(v tagged) int { return v.ID }); len(got) != 0 {
+		t.Errorf("UniqueBy(nil) = %v, want empty", got)
+	}
+}
+
+func TestWithout(t *testing.T) {
+	large := make([]int, 1000)
+	for i := range large {
+		large[i] = i % 10
+	}
+	for _, tt := range []struct {
+		name string
+		data []int
+		vs   []int
+		want []int
+	}{
+		{"nil", nil, []int{1}, []int{}},
+		{"no values", []int{1, 2, 3}, nil, []int{1, 2, 3}},
+		{"not present", []int{1, 2, 3}, []int{4}, []int{1, 2, 3}},
+		{"every occurrence", []int{1, 2, 1, 3, 1}, []int{1}, []int{2, 3}},
+		{"several values", []int{1, 2, 1, 3, 1}, []int{1, 3}, []int{2}},
+		{"everything", []int{1, 2, 1}, []int{2, 1}, []int{}},
+		{"large", large, Reversed(large[:9]), Repeat(9, 100)},
+	} {
+		orig := Clone(tt.data)
+		got := Without(tt.data, tt.vs...)
+		if !Equal(got, tt.want) {
+			t.Errorf("%s: Without(%v, %v) = %v, want %v", tt.name, tt.data, tt.vs, got, tt.want)
+		}
+		if !Equal(tt.data, orig) {
+			t.Errorf("%s: Without modified its input", tt.name)
+		}
+	}
+
+	s := []int{1, 2}
+	got := Without(s, 3)
+	got[0] = 100
+	if s[0] != 1 {
+		t.Errorf("Without aliases its input")
+	}
+}
+
+type thing struct {
+	ID int
+}
+
+func TestDeleteFunc(t *testing.T) {
+	for _, tt := range []struct {
+		data []int
+		want []int
+	}{
+		{nil, nil},
+		{[]int{1, 2, 3, 4, 5}, []int{1, 3, 5}},
+		{[]int{2, 4}, []int{}},
+		{[]int{1, 3}, []int{1, 3}},
+	} {
+		got := DeleteFunc(Clone(tt.data), isEven)
+		if !Equal(got, tt.want) {
+			t.Errorf("DeleteFunc(%v, isEven) = %v, want %v", tt.data, got, tt.want)
+		}
+	}
+}
+
+func TestDeleteFuncZeroesTail(t *testing.T) {
+	s := []*thing{{1}, {2}, {3}, {4}, {5}}
+	got := DeleteFunc(s, func(v *thing) bool { return v.ID%2 == 0 })
+	if len(got) != 3 || got[0].ID != 1 || got[1].ID != 3 || got[2].ID != 5 {
+		t.Fatalf("DeleteFunc = %v", got)
+	}
+	for i, v := range got[len(got):len(s)] {
+		if v != nil {
+			t.Errorf("tail element %d = %v, want nil", len(got)+i, v)
+		}
+	}
+}
+
+func BenchmarkDeleteFunc(b *testing.B) {
+	data := make([]int, 10000)
+	s := make([]int, len(data))
+	for i := range data {
+		data[i] = i
+	}
+	b.Run("DeleteFunc", func(b *testing.B) {
+		b.ReportAllocs()
+		for i := 0; i < b.N; i++ {
+			copy(s, data)
+			DeleteFunc(s, isEven)
+		}
+	})
+	b.Run("Filter", func(b *testing.B) {
+		b.ReportAllocs()
+		for i := 0; i < b.N; i++ {
+			copy(s, data)
+			Filter(s, func(v int) bool { return !isEven(v) })
+		}
+	})
+}
+
+func TestDelete(t *testing.T) {
+	for _, tt := range []struct {
+		data []int
+		i, j int
+		want []int
+	}{
+		{[]int{1, 2, 3}, 0, 0, []int{1, 2, 3}},
+		{[]int{1, 2, 3}, 0, 1, []int{2, 3}},
+		{[]int{1, 2, 3}, 3, 3, []int{1, 2, 3}},
+		{[]int{1, 2, 3}, 0, 2, []int{3}},
+		{[]int{1, 2, 3}, 0, 3, []int{}},
+		{[]int{1, 2, 3, 4}, 1, 3, []int{1, 4}},
+	} {
+		got := Delete(Clone(tt.data), tt.i, tt.j)
+		if !Equal(got, tt.want) {
+			t.Errorf("Delete(%v, %d, %d) = %v, want %v", tt.data, tt.i, tt.j, got, tt.want)
+		}
+	}
+}
+
+func TestDeleteZeroesTail(t *testing.T) {
+	s := make([]*bytes.Buffer, 5)
+	for i := range s {
+		s[i] = new(bytes.Buffer)
+	}
+	keep0, keep3, keep4 := s[0], s[3], s[4]
+	got := Delete(s, 1, 3)
+	if len(got) != 3 || got[0] != keep0 || got[1] != keep3 || got[2] != keep4 {
+		t.Fatalf("Delete(s, 1, 3) = %v", got)
+	}
+	for i, v := range got[len(got):len(s)] {
+		if v != nil {
+			t.Errorf("tail element %d = %p, want nil", len(got)+i, v)
+		}
+	}
+}
+
+func TestDeletePanics(t *testing.T) {
+	for _, tt := range []struct{ i, j int }{{-1, 1}, {2, 1}, {0, 4}, {4, 4}} {
+		func() {
+			defer func() {
+				if recover() == nil {
+					t.Errorf("Delete(_, %d, %d) did not panic", tt.i, tt.j)
+				}
+			}()
+			s := make([]int, 3, 10)
+			Delete(s, tt.i, tt.j)
+		}()
+	}
+}
+
+func TestCompactZeroesTail(t *testing.T) {
+	a, b, c := &thing{1}, &thing{2}, &thing{3}
+	s := []*thing{a, a, b, b, b, c}
+	got := Compact(s)
+	if want := []*thing{a, b, c}; !Equal(got, want) {
+		t.Fatalf("Compact = %v, want %v", got, want)
+	}
+	for i, v := range got[len(got):len(s)] {
+		if v != nil {
+			t.Errorf("Compact: tail element %d = %v, want nil", len(got)+i, v)
+		}
+	}
+}
+
+func TestCompactFuncZeroesTail(t *testing.T) {
+	s := []*thing{{1}, {1}, {2}, {3}, {3}}
+	got := CompactFunc(s, func(x, y *thing) bool { return x.ID == y.ID })
+	if len(got) != 3 || got[0].ID != 1 || got[1].ID != 2 || got[2].ID != 3 {
+		t.Fatalf("CompactFunc = %v", got)
+	}
+	for i, v := range got[len(got):len(s)] {
+		if v != nil {
+			t.Errorf("CompactFunc: tail element %d = %v, want nil", len(got)+i, v)
+		}
+	}
+}
+
+func TestRunLengthEncode(t *testing.T) {
+	for _, tt := range []struct {
+		s    []string
+		want []Run[string]
+	}{
+		{nil, []Run[string]{}},
+		{[]string{"up"}, []Run[string]{{"up", 1}}},
+		{[]string{"up", "down", "up"}, []Run[string]{{"up", 1}, {"down", 1}, {"up", 1}}},
+		{[]string{"up", "up", "up"}, []Run[string]{{"up", 3}}},
+		{[]string{"up", "up", "down", "up"}, []Run[string]{{"up", 2}, {"down", 1}, {"up", 1}}},
+	} {
+		got := RunLengthEncode(tt.s)
+		if !Equal(got, tt.want) {
+			t.Errorf("RunLengthEncode(%v) = %v, want %v", tt.s, got, tt.want)
+		}
+		if back := RunLengthDecode(got); !Equal(back, tt.s) {
+			t.Errorf("RunLengthDecode(RunLengthEncode(%v)) = %v", tt.s, back)
+		}
+	}
+}
+
+func TestRunLengthRoundTrip(t *testing.T) {
+	r := rand.New(rand.NewSource(1))
+	for iter := 0; iter < 100; iter++ {
+		s := make([]int, r.Intn(50))
+		for i := range s {
+			s[i] = r.Intn(3)
+		}
+		runs := RunLengthEncode(s)
+		for i := 1; i < len(runs); i++ {
+			if runs[i].Value == runs[i-1].Value {
+				t.Fatalf("RunLengthEncode(%v) has adjacent runs of %d", s, runs[i].Value)
+			}
+		}
+		if back := RunLengthDecode(runs); !Equal(back, s) {
+			t.Fatalf("RunLengthDecode(RunLengthEncode(%v)) = %v", s, back)
+		}
+	}
+}
+
+func TestRunLengthDecodePanics(t *testing.T) {
+	defer func() {
+		if recover() == nil {
+			t.Errorf("RunLengthDecode with negative count did not panic")
+		}
+	}()
+	RunLengthDecode([]Run[int]{{1, -1}})
+}
+
+func TestTruncate(t *testing.T) {
+	s := []*bytes.Buffer{new(bytes.Buffer), new(bytes.Buffer), new(bytes.Buffer), new(bytes.Buffer)}
+	first := s[0]
+	got := Truncate(s, 1)
+	if len(got) != 1 || got[0] != first {
+		t.Fatalf("Truncate(s, 1) = %v", got)
+	}
+	for i, v := range got[1:len(s)] {
+		if v != nil {
+			t.Errorf("Truncate: dropped element %d = %v, want nil", 1+i, v)
+		}
+	}
+	if got := Truncate(s[:1], 1); len(got) != 1 || got[0] != first {
+		t.Errorf("Truncate to the same length changed the slice")
+	}
+	if got := Truncate([]int(nil), 0); len(got) != 0 {
+		t.Errorf("Truncate(nil, 0) = %v", got)
+	}
+
+	for _, n := range []int{-1, 2} {
+		func() {
+			defer func() {
+				if recover() == nil {
+					t.Errorf("Truncate(s, %d) did not panic", n)
+				}
+			}()
+			Truncate([]int{1}, n)
+		}()
+	}
+}
+
+// BenchmarkCompact compacts slices of the same length that differ only in the
+// number of duplicates; zeroing the tail adds work proportional to that
+// number alone.
+func BenchmarkCompact(b *testing.B) {
+	const n = 10000
+	for _, removed := range []int{0, 100, 1000, 9000} {
+		data := make([]*int, n)
+		for i := range data {
+			v := i
+			data[i] = &v
+		}
+		for i := 1; i <= removed; i++ {
+			data[i] = data[0]
+		}
+		s := make([]*int, n)
+		b.Run(fmt.Sprintf("Removed%d", removed), func(b *testing.B) {
+			for i := 0; i < b.N; i++ {
+				copy(s, data)
+				Compact(s)
+			}
+		})
+	}
+}
+
+var replaceTests = []struct {
+	name string
+	s    []int
+	i, j int
+	v    []int
+	want []int
+}{
+	{"larger at start", []int{1, 2, 3}, 0, 1, []int{7, 8, 9}, []int{7, 8, 9, 2, 3}},
+	{"larger at end", []int{1, 2, 3}, 2, 3, []int{7, 8}, []int{1, 2, 7, 8}},
+	{"larger middle", []int{1, 2, 3, 4}, 1, 2, []int{7, 8}, []int{1, 7, 8, 3, 4}},
+	{"equal", []int{1, 2, 3, 4}, 1, 3, []int{7, 8}, []int{1, 7, 8, 4}},
+	{"smaller", []int{1, 2, 3, 4}, 1, 3, []int{7}, []int{1, 7, 4}},
+	{"smaller at start", []int{1, 2, 3, 4}, 0, 3, []int{7}, []int{7, 4}},
+	{"smaller at end", []int{1, 2, 3, 4}, 2, 4, []int{7}, []int{1, 2, 7}},
+	{"delete only", []int{1, 2, 3, 4}, 1, 3, nil, []int{1, 4}},
+	{"insert only", []int{1, 2, 3}, 1, 1, []int{7}, []int{1, 7, 2, 3}},
+	{"append", []int{1, 2, 3}, 3, 3, []int{7}, []int{1, 2, 3, 7}},
+	{"everything", []int{1, 2, 3}, 0, 3, []int{7}, []int{7}},
+	{"nil", nil, 0, 0, []int{7}, []int{7}},
+}
+
+func TestReplace(t *testing.T) {
+	for _, tt := range replaceTests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := Replace(Clone(tt.s), tt.i, tt.j, tt.v...)
+			if !Equal(got, tt.want) {
+				t.Errorf("Replace(%v, %d, %d, %v...) = %v, want %v", tt.s, tt.i, tt.j, tt.v, got, tt.want)
+			}
+
+			// With spare capacity the backing array must be reused.
+			s := make([]int, len(tt.s), len(tt.s)+len(tt.v))
+			copy(s, tt.s)
+			got = Replace(s, tt.i, tt.j, tt.v...)
+			if !Equal(got, tt.want) {
+				t.Errorf("Replace(%v, %d, %d, %v...) with capacity = %v, want %v", tt.s, tt.i, tt.j, tt.v, got, tt.want)
+			}
+			if len(got) > 0 && &got[0] != &s[:1][0] {
+				t.Errorf("Replace(%v, %d, %d, %v...) reallocated although capacity was sufficient", tt.s, tt.i, tt.j, tt.v)
+			}
+			// Anything past a shorter result must have been zeroed.
+			for k := len(got); k < len(s); k++ {
+				if s[k] != 0 {
+					t.Errorf("Replace(%v, %d, %d, %v...): tail element %d = %d, want 0", tt.s, tt.i, tt.j, tt.v, k, s[k])
+				}
+			}
+		})
+	}
+}
+
+func TestReplacePanics(t *testing.T) {
+	for _, tt := range []struct{ i, j int }{{-1, 1}, {2, 1}, {0, 4}, {4, 4}} {
+		func() {
+			defer func() {
+				if recover() == nil {
+					t.Errorf("Replace(_, %d, %d) did not panic", tt.i, tt.j)
+				}
+			}()
+			s := make([]int, 3, 10)
+			Replace(s, tt.i, tt.j, 7)
+		}()
+	}
+}
+
+func BenchmarkReplace(b *testing.B) {
+	data := make([]int, 10000)
+	v := []int{1, 2, 3, 4, 5}
+	s := make([]int, len(data), len(data)+len(v))
+	b.Run("Replace", func(b *testing.B) {
+		for i := 0; i < b.N; i++ {
+			s = s[:len(data)]
+			copy(s, data)
+			Replace(s, 10, 12, v...)
+		}
+	})
+	b.Run("DeleteInsert", func(b *testing.B) {
+		for i := 0; i < b.N; i++ {
+			s = s[:len(data)]
+			copy(s, data)
+			Insert(Delete(s, 10, 12), 10, v...)
+		}
+	})
+}
+
+func TestReplaceAll(t *testing.T) {
+	for _, tt := range []struct {
+		data     []int
+		old, new int
+		want     []int
+	}{
+		{nil, 1, 2, nil},
+		{[]int{3, 4}, 1, 2, []int{3, 4}},
+		{[]int{1, 3, 1, 1, 4}, 1, 2, []int{2, 3, 2, 2, 4}},
+		{[]int{1, 3, 1}, 1, 1, []int{1, 3, 1}},
+	} {
+		s := Clone(tt.data)
+		got := ReplaceAll(s, tt.old, tt.new)
+		if !Equal(got, tt.want) {
+			t.Errorf("ReplaceAll(%v, %d, %d) = %v, want %v", tt.data, tt.old, tt.new, got, tt.want)
+		}
+		if !Equal(s, tt.want) {
+			t.Errorf("ReplaceAll(%v, %d, %d) did not modify s in place: %v", tt.data, tt.old, tt.new, s)
+		}
+	}
+}
+
+func TestReplaceAllFunc(t *testing.T) {
+	s := []int{1, 2, 3, 4, 6}
+	got := ReplaceAllFunc(s, isEven, 0)
+	if want := []int{1, 0, 3, 0, 0}; !Equal(got, want) || !Equal(s, want) {
+		t.Errorf("ReplaceAllFunc = %v (s = %v), want %v", got, s, want)
+	}
+}
+
+// rotateNaive rotates s left by k using a temporary copy.
+func rotateNaive(s []int, k int) []int {
+	n := len(s)
+	r := make([]int, n)
+	for i := range s {
+		r[i] = s[((i+k)%n+n)%n]
+	}
+	return r
+}
+
+func TestRotate(t *testing.T) {
+	for _, tt := range []struct {
+		k    int
+		want []int
+	}{
+		{0, []int{1, 2, 3, 4, 5}},
+		{1, []int{2, 3, 4, 5, 1}},
+		{2, []int{3, 4, 5, 1, 2}},
+		{5, []int{1, 2, 3, 4, 5}},
+		{7, []int{3, 4, 5, 1, 2}},
+		{-1, []int{5, 1, 2, 3, 4}},
+		{-5, []int{1, 2, 3, 4, 5}},
+		{-7, []int{4, 5, 1, 2, 3}},
+	} {
+		s := []int{1, 2, 3, 4, 5}
+		Rotate(s, tt.k)
+		if !Equal(s, tt.want) {
+			t.Errorf("Rotate(_, %d) = %v, want %v", tt.k, s, tt.want)
+		}
+	}
+
+	Rotate([]int(nil), 3) // must not divide by zero
+}
+
+func TestRotateRandom(t *testing.T) {
+	r := rand.New(rand.NewSource(1))
+	for iter := 0; iter < 200; iter++ {
+		n := r.Intn(20) + 1
+		s := make([]int, n)
+		for i := range s {
+			s[i] = r.Int()
+		}
+		k := r.Intn(4*n) - 2*n
+		want := rotateNaive(s, k)
+		got := Clone(s)
+		Rotate(got, k)
+		if !Equal(got, want) {
+			t.Fatalf("Rotate(%v, %d) = %v, want %v", s, k, got, want)
+		}
+	}
+}
+
+func TestSwap(t *testing.T) {
+	s := []int{1, 2, 3}
+	Swap(s, 0, 2)
+	if !Equal(s, []int{3, 2, 1}) {
+		t.Errorf("Swap(0, 2) = %v, want [3 2 1]", s)
+	}
+	Swap(s, 1, 1)
+	if !Equal(s, []int{3, 2, 1}) {
+		t.Errorf("Swap(1, 1) = %v, want [3 2 1]", s)
+	}
+	for _, ij := range [][2]int{{-1, 0}, {0, 3}} {
+		func() {
+			defer func() {
+				msg, _ := recover().(string)
+				if !strings.Contains(msg, strconv.Itoa(ij[0]+ij[1])) {
+					t.Errorf("Swap(%d, %d) panic = %q, want it to name the index", ij[0], ij[1], msg)
+				}
+			}()
+			Swap(s, ij[0], ij[1])
+		}()
+	}
+}
+
+// moveNaive is the reference implementation of MoveElement.
+func moveNaive(s []int, from, to int) []int {
+	v := s[from]
+	s2 := Delete(Clone(s), from, from+1)
+	return Insert(s2, to, v)
+}
+
+func TestMoveElement(t *testing.T) {
+	for n := 1; n <= 6; n++ {
+		s := make([]int, n)
+		for i := range s {
+			s[i] = i
+		}
+		for from := 0; from < n; from++ {
+			for to := 0; to < n; to++ {
+				want := moveNaive(s, from, to)
+				got := MoveElement(Clone(s), from, to)
+				if !Equal(got, want) {
+					t.Errorf("MoveElement(%v, %d, %d) = %v, want %v", s, from, to, got, want)
+				}
+			}
+		}
+	}
+	defer func() {
+		if recover() == nil {
+			t.Errorf("MoveElement with out of range index did not panic")
+		}
+	}()
+	MoveElement([]int{1}, 0, 1)
+}
+
+func TestTakeDrop(t *testing.T) {
+	data := []int{1, 2, 3, 4}
+	for _, tt := range []struct {
+		n        int
+		wantTake []int
+		wantDrop []int
+	}{
+		{-1, []int{}, []int{1, 2, 3, 4}},
+		{0, []int{}, []int{1, 2, 3, 4}},
+		{1, []int{1}, []int{2, 3, 4}},
+		{4, []int{1, 2, 3, 4}, []int{}},
+		{5, []int{1, 2, 3, 4}, []int{}},
+	} {
+		if got := Take(data, tt.n); !Equal(got, tt.wantTake) {
+			t.Errorf("Take(%v, %d) = %v, want %v", data, tt.n, got, tt.wantTake)
+		}
+		if got := Drop(data, tt.n); !Equal(got, tt.wantDrop) {
+			t.Errorf("Drop(%v, %d) = %v, want %v", data, tt.n, got, tt.wantDrop)
+		}
+	}
+
+	got := Take(data, 2)
+	got[0] = 100
+	got = Drop(data, 2)
+	got[0] = 100
+	if want := []int{1, 2, 3, 4}; !Equal(data, want) {
+		t.Errorf("Take or Drop aliased their input: %v", data)
+	}
+}
+
+func TestTakeWhileDropWhile(t *testing.T) {
+	for _, tt := range []struct {
+		data     []int
+		wantTake []int
+		wantDrop []int
+	}{
+		{nil, []int{}, []int{}},
+		{[]int{2, 4, 5, 6}, []int{2, 4}, []int{5, 6}},
+		{[]int{2, 4, 6}, []int{2, 4, 6}, []int{}},
+		{[]int{1, 3, 4}, []int{}, []int{1, 3, 4}},
+	} {
+		if got := TakeWhile(tt.data, isEven); !Equal(got, tt.wantTake) {
+			t.Errorf("TakeWhile(%v, isEven) = %v, want %v", tt.data, got, tt.wantTake)
+		}
+		if got := DropWhile(tt.data, isEven); !Equal(got, tt.wantDrop) {
+			t.Errorf("DropWhile(%v, isEven) = %v, want %v", tt.data, got, tt.wantDrop)
+		}
+	}
+}
+
+func TestAllAnyNone(t *testing.T) {
+	for _, tt := range []struct {
+		data      []int
+		wantAll   bool
+		wantAny   bool
+		wantNone  bool
+		wantCalls [3]int // calls made by All, Any and None
+	}{
+		{nil, true, false, true, [3]int{0, 0, 0}},
+		{[]int{2, 4, 6}, true, true, false, [3]int{3, 1, 1}},
+		{[]int{1, 3, 5}, false, false, true, [3]int{1, 3, 3}},
+		{[]int{2, 3, 4}, false, true, false, [3]int{2, 1, 1}},
+		{[]int{1, 2, 3}, false, true, false, [3]int{1, 2, 2}},
+	} {
+		var calls int
+		pred := func(v int) bool {
+			calls++
+			return isEven(v)
+		}
+		for k, f := range []struct {
+			name string
+			fn   func([]int, func(int) bool) bool
+			want bool
+		}{
+			{"All", All[int], tt.wantAll},
+			{"Any", Any[int], tt.wantAny},
+			{"None", None[int], tt.wantNone},
+		} {
+			calls = 0
+			if got := f.fn(tt.data, pred); got != f.want {
+				t.Errorf("%s(%v, isEven) = %t, want %t", f.name, tt.data, got, f.want)
+			}
+			if calls != tt.wantCalls[k] {
+				t.Errorf("%s(%v, isEven) called pred %d times, want %d", f.name, tt.data, calls, tt.wantCalls[k])
+			}
+		}
+	}
+}
+
+func TestScan(t *testing.T) {
+	transactions := []int{100, -30, 50, -120}
+	balances := Scan(transactions, 0, func(acc, v int) int { return acc + v })
+	if want := []int{100, 70, 120, 0}; !Equal(balances, want) {
+		t.Errorf("Scan(balance) = %v, want %v", balances, want)
+	}
+
+	runningMax := Scan([]int{3, 1, 4, 1, 5}, math.MinInt, func(acc, v int) int {
+		if v > acc {
+			return v
+		}
+		return acc
+	})
+	if want := []int{3, 3, 4, 4, 5}; !Equal(runningMax, want) {
+		t.Errorf("Scan(max) = %v, want %v", runningMax, want)
+	}
+
+	concat := func(acc, v string) string { return acc + v }
+	s := []string{"a", "b", "c"}
+	got := Scan(s, ">", concat)
+	if want := []string{">a", ">ab", ">abc"}; !Equal(got, want) {
+		t.Errorf("Scan(concat) = %q, want %q", got, want)
+	}
+	if last, want := got[len(got)-1], Reduce(s, ">", concat); last != want {
+		t.Errorf("last element of Scan = %q, want Reduce result %q", last, want)
+	}
+
+	if got := Scan([]int(nil), 1, func(acc, v int) int { return acc + v }); len(got) != 0 {
+		t.Errorf("Scan(nil) = %v, want empty", got)
+	}
+}
+
+func TestToMap(t *testing.T) {
+	users := []user{{"a01", "Alice"}, {"b07", "Bob"}, {"a01", "Alicia"}}
+	got := ToMap(users, func(u user) string { return u.ID })
+	want := map[string]user{"a01": {"a01", "Alicia"}, "b07": {"b07", "Bob"}}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("ToMap = %v, want %v", got, want)
+	}
+	if got := ToMap([]user(nil), func(u user) string { return u.ID }); got == nil || len(got) != 0 {
+		t.Errorf("ToMap(nil) = %#v, want empty non-nil map", got)
+	}
+}
+
+func TestToMapValue(t *testing.T) {
+	users := []user{{"a01", "Alice"}, {"b07", "Bob"}, {"a01", "Alicia"}}
+	got := ToMapValue(users, func(u user) (string, int) { return u.ID, len(u.Name) })
+	want := map[string]int{"a01": 6, "b07": 3}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("ToMapValue = %v, want %v", got, want)
+	}
+	if got := ToMapValue([]user(nil), func(u user) (string, int) { return u.ID, 0 }); got == nil || len(got) != 0 {
+		t.Errorf("ToMapValue(nil) = %#v, want empty non-nil map", got)
+	}
+}
+
+func TestToSet(t *testing.T) {
+	set := ToSet([]string{"a", "b", "a", "c", "b"})
+	want := map[string]struct{}{"a": {}, "b": {}, "c": {}}
+	if !reflect.DeepEqual(set, want) {
+		t.Errorf("ToSet = %v, want %v", set, want)
+	}
+	if got := ToSet([]string(nil)); got == nil || len(got) != 0 {
+		t.Errorf("ToSet(nil) = %#v, want empty non-nil map", got)
+	}
+}
+
+func TestSetToSlice(t *testing.T) {
+	data := []int{3, 1, 2, 3, 1}
+	got := SetToSlice(ToSet(data))
+	Sort(got)
+	if want := []int{1, 2, 3}; !Equal(got, want) {
+		t.Errorf("SetToSlice(ToSet(%v)) = %v, want %v in any order", data, got, want)
+	}
+	if got := SetToSlice(map[int]struct{}(nil)); len(got) != 0 {
+		t.Errorf("SetToSlice(nil) = %v, want empty", got)
+	}
+}
+
+func TestFrequencies(t *testing.T) {
+	codes := []int{200, 404, 200, 500, 200, 404}
+	got := Frequencies(codes)
+	want := map[int]int{200: 3, 404: 2, 500: 1}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("Frequencies = %v, want %v", got, want)
+	}
+	total := 0
+	for _, n := range got {
+		total += n
+	}
+	if total != len(codes) {
+		t.Errorf("Frequencies counts sum to %d, want %d", total, len(codes))
+	}
+	if got := Frequencies([]int(nil)); got == nil || len(got) != 0 {
+		t.Errorf("Frequencies(nil) = %#v, want empty non-nil map", got)
+	}
+}
+
+func TestFrequenciesBy(t *testing.T) {
+	codes := []int{200, 201, 404, 500, 503, 204}
+	got := FrequenciesBy(codes, func(c int) int { return c / 100 })
+	want := map[int]int{2: 3, 4: 1, 5: 2}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("FrequenciesBy = %v, want %v", got, want)
+	}
+	total := 0
+	for _, n := range got {
+		total += n
+	}
+	if total != len(codes) {
+		t.Errorf("FrequenciesBy counts sum to %d, want %d", total, len(codes))
+	}
+	if got := FrequenciesBy([]int(nil), func(c int) int { return c }); got == nil || len(got) != 0 {
+		t.Errorf("FrequenciesBy(nil) = %#v, want empty non-nil map", got)
+	}
+}
+
+func TestInterleave(t *testing.T) {
+	for _, tt := range []struct {
+		name string
+		ss   [][]int
+		want []int
+	}{
+		{"no inputs", nil, []int{}},
+		{"single input", [][]int{{1, 2, 3}}, []int{1, 2, 3}},
+		{"equal lengths", [][]int{{1, 2}, {10, 20}, {100, 200}}, []int{1, 10, 100, 2, 20, 200}},
+		{"different lengths", [][]int{{1}, {10, 20, 30, 40}, {}, {100, 200}}, []int{1, 10, 100, 20, 200, 30, 40}},
+		{"empty inputs", [][]int{nil, {}}, []int{}},
+	} {
+		got := Interleave(tt.ss...)
+		if !Equal(got, tt.want) {
+			t.Errorf("%s: Interleave(%v) = %v, want %v", tt.name, tt.ss, got, tt.want)
+		}
+	}
+}
+
+func TestIntersect(t *testing.T) {
+	for _, tt := range []struct {
+		name string
+		a, b []int
+		want []int
+	}{
+		{"empty", nil, nil, nil},
+		{"empty a", nil, []int{1}, nil},
+		{"empty b", []int{1}, nil, nil},
+		{"disjoint", []int{1, 2, 3}, []int{4, 5}, nil},
+		{"a smaller", []int{3, 1, 2}, []int{1, 2, 3, 4, 5}, []int{3, 1, 2}},
+		{"b smaller", []int{5, 4, 3, 2, 1}, []int{1, 3}, []int{3, 1}},
+		{"duplicates in both", []int{2, 1, 2, 3, 1}, []int{1, 1, 2, 2, 2, 4}, []int{2, 1}},
+		{"duplicates a smaller", []int{2, 2, 1}, []int{1, 1, 2, 2, 4, 5, 6}, []int{2, 1}},
+	} {
+		got := Intersect(tt.a, tt.b)
+		if !Equal(got, tt.want) {
+			t.Errorf("%s: Intersect(%v, %v) = %v, want %v", tt.name, tt.a, tt.b, got, tt.want)
+		}
+	}
+}
+
+// intersectNaive is the O(len(a)*len(b)) reference implementation.
+func intersectNaive(a, b []int) []int {
+	var s []int
+	for _, v := range a {
+		if Contains(b, v) && !Contains(s, v) {
+			s = append(s, v)
+		}
+	}
+	return s
+}
+
+func BenchmarkIntersect(b *testing.B) {
+	for _, n := range []int{1000, 10000, 100000} {
+		x := make([]int, n)
+		y := make([]int, n)
+		for i := range x {
+			x[i] = i * 2
+			y[i] = i * 3
+		}
+		b.Run(fmt.Sprintf("Set%d", n), func(b *testing.B) {
+			for i := 0; i < b.N; i++ {
+				Intersect(x, y)
+			}
+		})
+		if n > 10000 {
+			continue // the naive version takes seconds per operation
+		}
+		b.Run(fmt.Sprintf("Naive%d", n), func(b *testing.B) {
+			for i := 0; i < b.N; i++ {
+				intersectNaive(x, y)
+			}
+		})
+	}
+}
+
+func TestUnion(t *testing.T) {
+	for _, tt := range []struct {
+		name string
+		ss   [][]int
+		want []int
+	}{
+		{"no inputs", nil, nil},
+		{"empty inputs", [][]int{nil, {}}, nil},
+		{"single input", [][]int{{3, 1, 3, 2, 1}}, []int{3, 1, 2}},
+		{"overlapping", [][]int{{1, 2, 3}, {3, 4, 1}, {5, 2}}, []int{1, 2, 3, 4, 5}},
+		{"disjoint", [][]int{{2, 1}, {4, 3}}, []int{2, 1, 4, 3}},
+	} {
+		got := Union(tt.ss...)
+		if !Equal(got, tt.want) {
+			t.Errorf("%s: Union(%v) = %v, want %v", tt.name, tt.ss, got, tt.want)
+		}
+		if len(tt.ss) == 1 {
+			if want := Unique(tt.ss[0]); !Equal(got, want) {
+				t.Errorf("%s: Union(%v) = %v, want Unique result %v", tt.name, tt.ss, got, want)
+			}
+		}
+	}
+}
+
+func TestDifference(t *testing.T) {
+	for _, tt := range []struct {
+		name     string
+		a, b     []int
+		wantDiff []int
+		wantSym  []int
+	}{
+		{"empty", nil, nil, nil, nil},
+		{"empty b", []int{1, 2}, nil, []int{1, 2}, []int{1, 2}},
+		{"empty a", nil, []int{1, 2}, nil, []int{1, 2}},
+		{"equal", []int{1, 2}, []int{2, 1}, nil, nil},
+		{"duplicates in a", []int{3, 1, 3, 2, 1}, []int{2}, []int{3, 1}, []int{3, 1}},
+		{"overlap", []int{1, 2, 3, 4}, []int{3, 4, 5, 6, 5}, []int{1, 2}, []int{1, 2, 5, 6}},
+	} {
+		if got := Difference(tt.a, tt.b); !Equal(got, tt.wantDiff) {
+			t.Errorf("%s: Difference(%v, %v) = %v, want %v", tt.name, tt.a, tt.b, got, tt.wantDiff)
+		}
+		if got := SymmetricDifference(tt.a, tt.b); !Equal(got, tt.wantSym) {
+			t.Errorf("%s: SymmetricDifference(%v, %v) = %v, want %v", tt.name, tt.a, tt.b, got, tt.wantSym)
+		}
+	}
+}
+
+func TestDifferenceSnapshots(t *testing.T) {
+	before := []string{"u1", "u2", "u3", "u4"}
+	after := []string{"u2", "u4", "u5", "u6"}
+	added := Difference(after, before)
+	removed := Difference(before, after)
+	if want := []string{"u5", "u6"}; !Equal(added, want) {
+		t.Errorf("added = %q, want %q", added, want)
+	}
+	if want := []string{"u1", "u3"}; !Equal(removed, want) {
+		t.Errorf("removed = %q, want %q", removed, want)
+	}
+	if got, want := SymmetricDifference(before, after), Concat(removed, added); !Equal(got, want) {
+		t.Errorf("SymmetricDifference = %q, want %q", got, want)
+	}
+}
+
+func TestEqualUnordered(t *testing.T) {
+	for _, tt := range []struct {
+		s1, s2 []int
+		want   bool
+	}{
+		{nil, nil, true},
+		{nil, []int{}, true},
+		{[]int{1, 2, 3}, []int{3, 1, 2}, true},
+		{[]int{1, 1, 2}, []int{1, 2, 1}, true},
+		{[]int{1, 1, 2}, []int{1, 2, 2}, false},
+		{[]int{1, 2}, []int{1, 2, 2}, false},
+		{[]int{1, 2}, []int{3, 4}, false},
+	} {
+		if got := EqualUnordered(tt.s1, tt.s2); got != tt.want {
+			t.Errorf("EqualUnordered(%v, %v) = %t, want %t", tt.s1, tt.s2, got, tt.want)
+		}
+	}
+}
+
+func TestEqualUnorderedFunc(t *testing.T) {
+	type record struct {
+		ID   int
+		Tags []string
+	}
+	id := func(r record) int { return r.ID }
+	a := []record{{1, nil}, {2, []string{"x"}}, {2, nil}}
+	b := []record{{2, nil}, {1, []string{"y"}}, {2, nil}}
+	if !EqualUnorderedFunc(a, b, id) {
+		t.Errorf("EqualUnorderedFunc(%v, %v, id) = false, want true", a, b)
+	}
+	c := []record{{1, nil}, {1, nil}, {2, nil}}
+	if EqualUnorderedFunc(a, c, id) {
+		t.Errorf("EqualUnorderedFunc(%v, %v, id) = true, want false", a, c)
+	}
+
+	calls := 0
+	EqualUnorderedFunc(a, a[:2], func(r record) int {
+		calls++
+		return r.ID
+	})
+	if calls != 0 {
+		t.Errorf("EqualUnorderedFunc with different lengths called key %d times, want 0", calls)
+	}
+}
+
+func BenchmarkEqualUnordered(b *testing.B) {
+	s1 := make([]int, 100000)
+	for i := range s1 {
+		s1[i] = i % 1000
+	}
+	s2 := Reversed(s1)
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		if !EqualUnordered(s1, s2) {
+			b.Fatal("EqualUnordered = false")
+		}
+	}
+}
+
+type point struct{ X, Y int }
+
+func TestHasPrefixSuffix(t *testing.T) {
+	s := []point{{0, 0}, {1, 2}, {3, 4}}
+	for _, tt := range []struct {
+		sub        []point
+		wantPrefix bool
+		wantSuffix bool
+	}{
+		{nil, true, true},
+		{[]point{{0, 0}}, true, false},
+		{[]point{{3, 4}}, false, true},
+		{[]point{{0, 0}, {1, 2}}, true, false},
+		{[]point{{1, 2}, {3, 4}}, false, true},
+		{s, true, true},
+		{[]point{{0, 0}, {1, 2}, {3, 4}, {5, 6}}, false, false},
+		{[]point{{9, 9}}, false, false},
+	} {
+		if got := HasPrefix(s, tt.sub); got != tt.wantPrefix {
+			t.Errorf("HasPrefix(%v, %v) = %t, want %t", s, tt.sub, got, tt.wantPrefix)
+		}
+		if got := HasSuffix(s, tt.sub); got != tt.wantSuffix {
+			t.Errorf("HasSuffix(%v, %v) = %t, want %t", s, tt.sub, got, tt.wantSuffix)
+		}
+	}
+	if !HasPrefix([]int(nil), nil) || !HasSuffix([]int(nil), nil) {
+		t.Errorf("empty prefix or suffix of nil slice not reported")
+	}
+}
+
+func TestHasPrefixSuffixFunc(t *testing.T) {
+	s := []point{{0, 0}, {1, 2}, {3, 4}}
+	sameX := func(p point, x int) bool { return p.X == x }
+	if !HasPrefixFunc(s, []int{0, 1}, sameX) {
+		t.Errorf("HasPrefixFunc(%v, [0 1]) = false, want true", s)
+	}
+	if HasPrefixFunc(s, []int{1}, sameX) {
+		t.Errorf("HasPrefixFunc(%v, [1]) = true, want false", s)
+	}
+	if !HasSuffixFunc(s, []int{1, 3}, sameX) {
+		t.Errorf("HasSuffixFunc(%v, [1 3]) = false, want true", s)
+	}
+	if HasSuffixFunc(s, []int{0, 1, 3, 5}, sameX) {
+		t.Errorf("HasSuffixFunc with suffix longer than s = true, want false")
+	}
+	if !HasPrefixFunc(s, []int(nil), sameX) || !HasSuffixFunc(s, []int(nil), sameX) {
+		t.Errorf("empty prefix or suffix not reported")
+	}
+}
+
+func TestMismatch(t *testing.T) {
+	nan := math.NaN()
+	for _, tt := range []struct {
+		s1, s2 []float64
+		want   int
+	}{
+		{nil, nil, -1},
+		{[]float64{1, 2}, []float64{1, 2}, -1},
+		{[]float64{1, 2}, []float64{0, 2}, 0},
+		{[]float64{1, 2, 3}, []float64{1, 5, 3}, 1},
+		{[]float64{1, 2}, []float64{1, 2, 3}, 2},
+		{[]float64{1, 2, 3}, []float64{1, 2}, 2},
+		{nil, []float64{1}, 0},
+		{[]float64{nan}, []float64{nan}, 0},
+	} {
+		if got := Mismatch(tt.s1, tt.s2); got != tt.want {
+			t.Errorf("Mismatch(%v, %v) = %d, want %d", tt.s1, tt.s2, got, tt.want)
+		}
+		if got := Mismatch(tt.s2, tt.s1); got != tt.want {
+			t.Errorf("Mismatch(%v, %v) = %d, want %d", tt.s2, tt.s1, got, tt.want)
+		}
+	}
+}
+
+func TestMismatchFunc(t *testing.T) {
+	s := []point{{0, 0}, {1, 2}, {3, 4}}
+	sameX := func(p point, x int) bool { return p.X == x }
+	if got := MismatchFunc(s, []int{0, 1, 3}, sameX); got != -1 {
+		t.Errorf("MismatchFunc(%v, [0 1 3]) = %d, want -1", s, got)
+	}
+	if got := MismatchFunc(s, []int{0, 2, 3}, sameX); got != 1 {
+		t.Errorf("MismatchFunc(%v, [0 2 3]) = %d, want 1", s, got)
+	}
+	if got := MismatchFunc(s, []int{0, 1}, sameX); got != 2 {
+		t.Errorf("MismatchFunc(%v, [0 1]) = %d, want 2", s, got)
+	}
+}
+
+func TestIndexSubslice(t *testing.T) {
+	for _, tt := range []struct {
+		s, sub []int
+		want   int
+	}{
+		{nil, nil, 0},
+		{[]int{1, 2}, nil, 0},
+		{nil, []int{1}, -1},
+		{[]int{1, 2, 3}, []int{1, 2, 3}, 0},
+		{[]int{1, 2, 3}, []int{2, 3}, 1},
+		{[]int{1, 2, 3}, []int{3}, 2},
+		{[]int{1, 1, 1, 2}, []int{1, 1, 2}, 1},
+		{[]int{1, 2, 1, 2, 3}, []int{1, 2, 3}, 2},
+		{[]int{1, 2, 3}, []int{1, 3}, -1},
+		{[]int{1, 2}, []int{1, 2, 3}, -1},
+	} {
+		if got := IndexSubslice(tt.s, tt.sub); got != tt.want {
+			t.Errorf("IndexSubslice(%v, %v) = %d, want %d", tt.s, tt.sub, got, tt.want)
+		}
+	}
+}
+
+func TestContainsSubsequence(t *testing.T) {
+	log := []string{"boot", "noise", "login", "noise", "noise", "checkout", "logout"}
+	for _, tt := range []struct {
+		sub            []string
+		want           bool
+		wantContiguous bool
+	}{
+		{nil, true, true},
+		{log, true, true},
+		{[]string{"login", "checkout", "logout"}, true, false},
+		{[]string{"checkout", "logout"}, true, true},
+		{[]string{"checkout", "login"}, false, false},
+		{[]string{"boot", "boot"}, false, false},
+		{[]string{"missing"}, false, false},
+		{append(Clone(log), "extra"), false, false},
+	} {
+		if got := ContainsSubsequence(log, tt.sub); got != tt.want {
+			t.Errorf("ContainsSubsequence(log, %q) = %t, want %t", tt.sub, got, tt.want)
+		}
+		if got := IndexSubslice(log, tt.sub) >= 0; got != tt.wantContiguous {
+			t.Errorf("IndexSubslice(log, %q) >= 0 = %t, want %t", tt.sub, got, tt.wantContiguous)
+		}
+	}
+}
+
+func TestLongestCommonPrefix(t *testing.T) {
+	for _, tt := range []struct {
+		name string
+		ss   [][]string
+		want []string
+	}{
+		{"no inputs", nil, nil},
+		{"one input", [][]string{{"usr", "local", "bin"}}, []string{"usr", "local", "bin"}},
+		{"shared", [][]string{
+			strings.Split("usr/local/bin/go", "/"),
+			strings.Split("usr/local/lib", "/"),
+			strings.Split("usr/local/bin", "/"),
+		}, []string{"usr", "local"}},
+		{"one is prefix of other", [][]string{{"a", "b"}, {"a", "b", "c"}}, []string{"a", "b"}},
+		{"nothing shared", [][]string{{"a"}, {"b"}}, []string{}},
+		{"empty among inputs", [][]string{{"a", "b"}, {}, {"a"}}, []string{}},
+	} {
+		got := LongestCommonPrefix(tt.ss...)
+		if !Equal(got, tt.want) {
+			t.Errorf("%s: LongestCommonPrefix(%q) = %q, want %q", tt.name, tt.ss, got, tt.want)
+		}
+	}
+
+	first := []int{1, 2, 3}
+	got := LongestCommonPrefix(first, []int{1, 2, 4})
+	got[0] = 100
+	if first[0] != 100 {
+		t.Errorf("LongestCommonPrefix does not alias its first input")
+	}
+}
+
+func TestSplit(t *testing.T) {
+	for _, tt := range []struct {
+		name string
+		s    []int
+		want [][]int
+	}{
+		{"empty", []int{}, [][]int{{}}},
+		{"nil", nil, [][]int{nil}},
+		{"absent", []int{1, 2}, [][]int{{1, 2}}},
+		{"middle", []int{1, 0, 2, 3}, [][]int{{1}, {2, 3}}},
+		{"leading", []int{0, 1}, [][]int{{}, {1}}},
+		{"trailing", []int{1, 0}, [][]int{{1}, {}}},
+		{"adjacent", []int{1, 0, 0, 2}, [][]int{{1}, {}, {2}}},
+		{"only separator", []int{0}, [][]int{{}, {}}},
+	} {
+		got := Split(tt.s, 0)
+		if !EqualFunc(got, tt.want, Equal[int]) {
+			t.Errorf("%s: Split(%v, 0) = %v, want %v", tt.name, tt.s, got, tt.want)
+		}
+		// Mirror strings.Split for the same input.
+		str := strings.Join(Map(tt.s, strconv.Itoa), "")
+		if want := strings.Split(str, "0"); len(got) != len(want) {
+			t.Errorf("%s: Split returned %d pieces, strings.Split returned %d", tt.name, len(got), len(want))
+		}
+	}
+
+	s := []int{1, 2, 0, 3}
+	parts := Split(s, 0)
+	parts[1][0] = 30
+	if s[3] != 30 {
+		t.Errorf("Split does not alias its input")
+	}
+	parts[0] = append(parts[0], 99)
+	if s[2] != 0 {
+		t.Errorf("appending to a piece overwrote the separator: %v", s)
+	}
+}
+
+func TestSplitN(t *testing.T) {
+	s := []int{1, 0, 2, 0, 3}
+	for _, tt := range []struct {
+		n    int
+		want [][]int
+	}{
+		{0, nil},
+		{1, [][]int{{1, 0, 2, 0, 3}}},
+		{2, [][]int{{1}, {2, 0, 3}}},
+		{3, [][]int{{1}, {2}, {3}}},
+		{4, [][]int{{1}, {2}, {3}}},
+		{-1, [][]int{{1}, {2}, {3}}},
+	} {
+		got := SplitN(s, 0, tt.n)
+		if !EqualFunc(got, tt.want, Equal[int]) {
+			t.Errorf("SplitN(%v, 0, %d) = %v, want %v", s, tt.n, got, tt.want)
+		}
+		if got == nil && tt.want != nil {
+			t.Errorf("SplitN(%v, 0, %d) = nil", s, tt.n)
+		}
+	}
+	if got := SplitN([]int{0, 0}, 0, 2); !EqualFunc(got, [][]int{{}, {0}}, Equal[int]) {
+		t.Errorf("SplitN([0 0], 0, 2) = %v, want [[] [0]]", got)
+	}
+}
+
+func TestSplitFunc(t *testing.T) {
+	isSpace := func(s string) bool { return strings.TrimSpace(s) == "" }
+	tokens := []string{"a", " ", "b", "\t", "\n", "c", " "}
+	want := [][]string{{"a"}, {"b"}, {}, {"c"}, {}}
+	if got := SplitFunc(tokens, isSpace); !EqualFunc(got, want, Equal[string]) {
+		t.Errorf("SplitFunc(%q) = %q, want %q", tokens, got, want)
+	}
+	want = [][]string{{"a"}, {"b", "\t", "\n", "c", " "}}
+	if got := SplitFuncN(tokens, isSpace, 2); !EqualFunc(got, want, Equal[string]) {
+		t.Errorf("SplitFuncN(%q, 2) = %q, want %q", tokens, got, want)
+	}
+	if got := SplitFunc([]string{}, isSpace); len(got) != 1 || len(got[0]) != 0 {
+		t.Errorf("SplitFunc([]) = %q, want [[]]", got)
+	}
+}
+
+func TestJoin(t *testing.T) {
+	for _, s := range [][]int{
+		{},
+		{1, 2, 3},
+		{0},
+		{0, 1, 0},
+		{1, 0, 0, 2},
+	} {
+		parts := Split(s, 0)
+		got := Join(parts, 0)
+		if !Equal(got, s) {
+			t.Errorf("Join(Split(%v, 0), 0) = %v", s, got)
+		}
+		if cap(got) != len(got) {
+			t.Errorf("Join(Split(%v, 0), 0) has cap %d, want %d", s, cap(got), len(got))
+		}
+	}
+
+	if got := Join([][]int{}, 0); got == nil || len(got) != 0 {
+		t.Errorf("Join([], 0) = %#v, want empty non-nil slice", got)
+	}
+	in := []int{1, 2}
+	got := Join([][]int{in}, 0)
+	if !Equal(got, in) {
+		t.Errorf("Join([[1 2]], 0) = %v, want %v", got, in)
+	}
+	got[0] = 10
+	if in[0] != 1 {
+		t.Errorf("Join result aliases its input")
+	}
+}
+
+func TestJoinSlice(t *testing.T) {
+	for _, tt := range []struct {
+		ss   [][]int
+		sep  []int
+		want []int
+	}{
+		{nil, []int{9, 9}, []int{}},
+		{[][]int{{1}}, []int{9, 9}, []int{1}},
+		{[][]int{{1}, {2, 3}, {}}, []int{9, 9}, []int{1, 9, 9, 2, 3, 9, 9}},
+		{[][]int{{1}, {2}}, nil, []int{1, 2}},
+	} {
+		got := JoinSlice(tt.ss, tt.sep)
+		if !Equal(got, tt.want) {
+			t.Errorf("JoinSlice(%v, %v) = %v, want %v", tt.ss, tt.sep, got, tt.want)
+		}
+		if cap(got) != len(tt.want) {
+			t.Errorf("JoinSlice(%v, %v) has cap %d, want %d", tt.ss, tt.sep, cap(got), len(tt.want))
+		}
+	}
+}
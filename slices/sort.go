@@ -0,0 +1,820 @@
+package slices
+
+import "github.com/syumai/go-generics/constraints"
+
+// Sort sorts a slice of any ordered type in ascending order.
+// Sort is not guaranteed to be stable and does not allocate.
+// When sorting floating-point numbers, NaNs are ordered before other values.
+func Sort[S constraints.Slice[T], T constraints.Ordered](s S) {
+	n := len(s)
+	quickSortOrdered(s, 0, n, maxDepth(n))
+}
+
+// SortFunc sorts the slice s in ascending order as determined by the less function.
+// less must describe a strict weak ordering; if it does not, SortFunc still
+// terminates and leaves s as some permutation of its original elements, but
+// the order of that permutation is unspecified.
+// SortFunc is not guaranteed to be stable.
+func SortFunc[S constraints.Slice[T], T any](s S, less func(a, b T) bool) {
+	n := len(s)
+	quickSortFunc(s, 0, n, maxDepth(n), less)
+}
+
+// SortStableFunc sorts the slice s while keeping the original order of equal
+// elements, using less to compare elements.
+// SortStableFunc does not allocate.
+func SortStableFunc[S constraints.Slice[T], T any](s S, less func(a, b T) bool) {
+	stableFunc(s, len(s), less)
+}
+
+// SortBy sorts the slice s in ascending order of the keys returned by key.
+// key is called twice per comparison, so O(n*log(n)) times in total, and
+// the keys are not cached; if key is expensive, precompute the keys and
+// sort with SortFunc instead. SortBy is not guaranteed to be stable and
+// does not allocate. NaN keys are ordered first, as by Sort.
+func SortBy[S constraints.Slice[T], T any, K constraints.Ordered](s S, key func(T) K) {
+	SortFunc(s, func(a, b T) bool { return cmpLess(key(a), key(b)) })
+}
+
+// SortStableBy is like SortBy but keeps the original order of elements
+// with equal keys.
+func SortStableBy[S constraints.Slice[T], T any, K constraints.Ordered](s S, key func(T) K) {
+	SortStableFunc(s, func(a, b T) bool { return cmpLess(key(a), key(b)) })
+}
+
+// SortByDesc is like SortBy but sorts in descending order of the keys.
+func SortByDesc[S constraints.Slice[T], T any, K constraints.Ordered](s S, key func(T) K) {
+	SortFunc(s, func(a, b T) bool { return cmpLess(key(b), key(a)) })
+}
+
+// ArgSort returns the permutation of indices that sorts s: s[idx[0]],
+// s[idx[1]], ... are in ascending order. s itself is not modified.
+// Indices of equal elements stay in increasing order, so the result is
+// deterministic. NaNs are ordered first, as by Sort.
+func ArgSort[T constraints.Ordered](s []T) []int {
+	return ArgSortFunc(s, cmpLess[T])
+}
+
+// ArgSortFunc is like ArgSort but orders the elements by less.
+func ArgSortFunc[T any](s []T, less func(a, b T) bool) []int {
+	idx := make([]int, len(s))
+	for i := range idx {
+		idx[i] = i
+	}
+	SortStableFunc(idx, func(i, j int) bool { return less(s[i], s[j]) })
+	return idx
+}
+
+// ApplyPermutation returns a new slice r with r[i] == s[idx[i]], such as
+// the sorted form of s when idx comes from ArgSort. The same idx can be
+// applied to several parallel slices to reorder them consistently.
+// ApplyPermutation panics if idx is not a permutation of the indices of s.
+func ApplyPermutation[S constraints.Slice[T], T any](s S, idx []int) S {
+	if len(idx) != len(s) {
+		panic("slices.ApplyPermutation: length mismatch")
+	}
+	seen := make([]bool, len(s))
+	s2 := make(S, len(s))
+	for i, j := range idx {
+		if j < 0 || j >= len(s) || seen[j] {
+			panic("slices.ApplyPermutation: not a permutation")
+		}
+		seen[j] = true
+		s2[i] = s[j]
+	}
+	return s2
+}
+
+// IsSorted reports whether s is sorted in ascending order.
+// NaNs are treated as less than any other value, matching the order Sort
+// produces, so a slice with all NaNs at the front may still be sorted.
+func IsSorted[T constraints.Ordered](s []T) bool {
+	for i := len(s) - 1; i > 0; i-- {
+		if cmpLess(s[i], s[i-1]) {
+			return false
+		}
+	}
+	return true
+}
+
+// IsSortedFunc reports whether s is sorted in ascending order, with less
+// as the comparison function.
+func IsSortedFunc[T any](s []T, less func(a, b T) bool) bool {
+	for i := len(s) - 1; i > 0; i-- {
+		if less(s[i], s[i-1]) {
+			return false
+		}
+	}
+	return true
+}
+
+// IsNonDecreasing reports whether every element of s is greater than or
+// equal to the one before it. It is the same as IsSorted, and NaNs are
+// likewise treated as less than any other value, and equal to each other.
+func IsNonDecreasing[T constraints.Ordered](s []T) bool {
+	return IsSorted(s)
+}
+
+// IsStrictlyIncreasing reports whether every element of s is greater than
+// the one before it, so that s is sorted and free of duplicates. NaNs are
+// ordered as in IsNonDecreasing.
+func IsStrictlyIncreasing[T constraints.Ordered](s []T) bool {
+	for i := 1; i < len(s); i++ {
+		if !cmpLess(s[i-1], s[i]) {
+			return false
+		}
+	}
+	return true
+}
+
+// IsNonIncreasing reports whether every element of s is less than or equal
+// to the one before it. NaNs are ordered as in IsNonDecreasing, so they
+// may only appear at the end.
+func IsNonIncreasing[T constraints.Ordered](s []T) bool {
+	for i := 1; i < len(s); i++ {
+		if cmpLess(s[i-1], s[i]) {
+			return false
+		}
+	}
+	return true
+}
+
+// IsStrictlyDecreasing reports whether every element of s is less than the
+// one before it. NaNs are ordered as in IsNonDecreasing.
+func IsStrictlyDecreasing[T constraints.Ordered](s []T) bool {
+	for i := 1; i < len(s); i++ {
+		if !cmpLess(s[i], s[i-1]) {
+			return false
+		}
+	}
+	return true
+}
+
+// BinarySearch searches for target in a sorted slice and returns the smallest
+// index at which target is found or would be inserted to keep the slice
+// sorted, along with a bool reporting whether target is actually present.
+// The slice must be sorted in increasing order, as by Sort.
+func BinarySearch[T constraints.Ordered](s []T, target T) (int, bool) {
+	n := len(s)
+	// Define cmpLess(s[-1], target) == true and cmpLess(s[n], target) == false.
+	// Invariant: cmpLess(s[i-1], target), !cmpLess(s[j], target).
+	i, j := 0, n
+	for i < j {
+		h := int(uint(i+j) >> 1) // avoid overflow when computing h
+		// i ≤ h < j
+		if cmpLess(s[h], target) {
+			i = h + 1 // preserves cmpLess(s[i-1], target)
+		} else {
+			j = h // preserves !cmpLess(s[j], target)
+		}
+	}
+	// i == j, cmpLess(s[i-1], target) and !cmpLess(s[j], target) => answer is i.
+	return i, i < n && (s[i] == target || (isNaN(s[i]) && isNaN(target)))
+}
+
+// BinarySearchFunc works like BinarySearch, but uses a custom comparison
+// function. The slice must be sorted in increasing order, where "increasing"
+// is defined by cmp. cmp should return 0 if the slice element matches
+// the target, a negative number if the slice element precedes the target,
+// or a positive number if the slice element follows the target, following
+// the -1/0/+1 convention of Compare.
+// The target may be of a different type than the elements, which allows
+// searching a slice of structs by one of their fields.
+func BinarySearchFunc[T, K any](s []T, target K, cmp func(T, K) int) (int, bool) {
+	n := len(s)
+	// Define cmp(s[-1], target) < 0 and cmp(s[n], target) >= 0 .
+	// Invariant: cmp(s[i - 1], target) < 0, cmp(s[j], target) >= 0.
+	i, j := 0, n
+	for i < j {
+		h := int(uint(i+j) >> 1) // avoid overflow when computing h
+		// i ≤ h < j
+		if cmp(s[h], target) < 0 {
+			i = h + 1 // preserves cmp(s[i - 1], target) < 0
+		} else {
+			j = h // preserves cmp(s[j], target) >= 0
+		}
+	}
+	// i == j, cmp(s[i-1], target) < 0, and cmp(s[j], target) (= cmp(s[i], target)) >= 0  =>  answer is i.
+	return i, i < n && cmp(s[i], target) == 0
+}
+
+// InsertOrdered inserts v into the sorted slice s at the position that
+// keeps it sorted, returning the modified slice. If s already contains
+// elements equal to v, v is placed after them. The slice must be sorted in
+// increasing order, as by Sort. Finding the position takes O(log n) time;
+// the insertion itself shifts the elements after it, as Insert does.
+func InsertOrdered[S constraints.Slice[T], T constraints.Ordered](s S, v T) S {
+	i, j := 0, len(s)
+	for i < j {
+		h := int(uint(i+j) >> 1) // avoid overflow when computing h
+		if cmpLess(v, s[h]) {
+			j = h
+		} else {
+			i = h + 1
+		}
+	}
+	return Insert(s, i, v)
+}
+
+// InsertOrderedFunc is like InsertOrdered but uses cmp to order the
+// elements, following the -1/0/+1 convention of Compare. The slice must be
+// sorted in increasing order, where "increasing" is defined by cmp.
+func InsertOrderedFunc[S constraints.Slice[T], T any](s S, v T, cmp func(T, T) int) S {
+	i, j := 0, len(s)
+	for i < j {
+		h := int(uint(i+j) >> 1) // avoid overflow when computing h
+		if cmp(v, s[h]) < 0 {
+			j = h
+		} else {
+			i = h + 1
+		}
+	}
+	return Insert(s, i, v)
+}
+
+// MergeSorted merges the sorted slices a and b into a new sorted slice in
+// O(len(a)+len(b)) time. Both inputs must be sorted in increasing order, as
+// by Sort. The merge is stable: elements of a come before equal elements
+// of b.
+func MergeSorted[S constraints.Slice[T], T constraints.Ordered](a, b S) S {
+	s := make(S, 0, len(a)+len(b))
+	i, j := 0, 0
+	for i < len(a) && j < len(b) {
+		if cmpLess(b[j], a[i]) {
+			s = append(s, b[j])
+			j++
+		} else {
+			s = append(s, a[i])
+			i++
+		}
+	}
+	s = append(s, a[i:]...)
+	return append(s, b[j:]...)
+}
+
+// MergeSortedFunc is like MergeSorted but orders the elements by less.
+// Both inputs must be sorted in increasing order, where "increasing" is
+// defined by less.
+func MergeSortedFunc[S constraints.Slice[T], T any](a, b S, less func(a, b T) bool) S {
+	s := make(S, 0, len(a)+len(b))
+	i, j := 0, 0
+	for i < len(a) && j < len(b) {
+		if less(b[j], a[i]) {
+			s = append(s, b[j])
+			j++
+		} else {
+			s = append(s, a[i])
+			i++
+		}
+	}
+	s = append(s, a[i:]...)
+	return append(s, b[j:]...)
+}
+
+// MaxN returns the k largest elements of s in decreasing order, without
+// modifying s. If k exceeds len(s), all of s is returned. NaNs are ordered
+// before other values, as by Sort, so they are only returned once no other
+// values are left. For small k, MaxN keeps a bounded heap of the best
+// candidates and runs in O(len(s) log k) time; it panics if k is negative.
+func MaxN[T constraints.Ordered](s []T, k int) []T {
+	if k < 0 {
+		panic("slices.MaxN: negative count")
+	}
+	return minNFunc(s, k, func(a, b T) bool { return cmpLess(b, a) })
+}
+
+// MinN returns the k smallest elements of s in increasing order, as MaxN
+// does for the largest. NaNs are ordered first, as by Sort.
+func MinN[T constraints.Ordered](s []T, k int) []T {
+	if k < 0 {
+		panic("slices.MinN: negative count")
+	}
+	return minNFunc(s, k, cmpLess[T])
+}
+
+// MaxNFunc is like MaxN but orders the elements by less.
+func MaxNFunc[T any](s []T, k int, less func(a, b T) bool) []T {
+	if k < 0 {
+		panic("slices.MaxNFunc: negative count")
+	}
+	return minNFunc(s, k, func(a, b T) bool { return less(b, a) })
+}
+
+// MinNFunc is like MinN but orders the elements by less.
+func MinNFunc[T any](s []T, k int, less func(a, b T) bool) []T {
+	if k < 0 {
+		panic("slices.MinNFunc: negative count")
+	}
+	return minNFunc(s, k, less)
+}
+
+// minNFunc returns the k smallest elements of s by less, in increasing
+// order. When k is a large fraction of len(s), sorting a copy is cheaper
+// than maintaining a heap.
+func minNFunc[T any](s []T, k int, less func(a, b T) bool) []T {
+	if k > len(s) {
+		k = len(s)
+	}
+	if 2*k >= len(s) {
+		s2 := Clone(s)
+		SortFunc(s2, less)
+		return s2[:k:k]
+	}
+	// h is a max-heap of the k smallest elements seen so far.
+	h := make([]T, k)
+	copy(h, s)
+	for i := (k - 1) / 2; i >= 0; i-- {
+		siftDownFunc(h, i, k, 0, less)
+	}
+	for _, v := range s[k:] {
+		if k > 0 && less(v, h[0]) {
+			h[0] = v
+			siftDownFunc(h, 0, k, 0, less)
+		}
+	}
+	heapSortFunc(h, 0, k, less)
+	return h
+}
+
+// SelectNth returns the element that would be at index n if s were sorted,
+// that is, the n-th smallest element counting from 0, in expected O(len(s))
+// time. It works on a copy and leaves s unmodified; use SelectNthInPlace to
+// avoid the allocation. NaNs are ordered first, as by Sort.
+// SelectNth panics if n is not in the range [0, len(s)).
+func SelectNth[S constraints.Slice[T], T constraints.Ordered](s S, n int) T {
+	if n < 0 || n >= len(s) {
+		panic("slices.SelectNth: index out of range")
+	}
+	return SelectNthInPlace(Clone(s), n)
+}
+
+// SelectNthInPlace is like SelectNth but reorders s itself: afterwards
+// s[n] holds the returned element, no element of s[:n] is greater than it
+// and no element of s[n+1:] is less than it.
+func SelectNthInPlace[S constraints.Slice[T], T constraints.Ordered](s S, n int) T {
+	if n < 0 || n >= len(s) {
+		panic("slices.SelectNthInPlace: index out of range")
+	}
+	selectOrdered(s, 0, len(s), n, maxDepth(len(s)))
+	return s[n]
+}
+
+// SelectNthFunc is like SelectNth but orders the elements by less.
+func SelectNthFunc[S constraints.Slice[T], T any](s S, n int, less func(a, b T) bool) T {
+	if n < 0 || n >= len(s) {
+		panic("slices.SelectNthFunc: index out of range")
+	}
+	return SelectNthFuncInPlace(Clone(s), n, less)
+}
+
+// SelectNthFuncInPlace is like SelectNthInPlace but orders the elements
+// by less.
+func SelectNthFuncInPlace[S constraints.Slice[T], T any](s S, n int, less func(a, b T) bool) T {
+	if n < 0 || n >= len(s) {
+		panic("slices.SelectNthFuncInPlace: index out of range")
+	}
+	selectFunc(s, 0, len(s), n, maxDepth(len(s)), less)
+	return s[n]
+}
+
+// isNaN reports whether x is a NaN without requiring the math package.
+func isNaN[T constraints.Ordered](x T) bool {
+	return x != x
+}
+
+// cmpLess is x < y, ordering NaNs before any other value so that the
+// result is a strict weak ordering even for floating-point types.
+func cmpLess[T constraints.Ordered](x, y T) bool {
+	return (isNaN(x) && !isNaN(y)) || x < y
+}
+
+// maxDepth returns a threshold at which quicksort should switch
+// to heapsort. It returns 2*ceil(lg(n+1)).
+func maxDepth(n int) int {
+	var depth int
+	for i := n; i > 0; i >>= 1 {
+		depth++
+	}
+	return depth * 2
+}
+
+// insertionSortOrdered sorts data[a:b] using insertion sort.
+func insertionSortOrdered[T constraints.Ordered](data []T, a, b int) {
+	for i := a + 1; i < b; i++ {
+		for j := i; j > a && cmpLess(data[j], data[j-1]); j-- {
+			data[j], data[j-1] = data[j-1], data[j]
+		}
+	}
+}
+
+// siftDownOrdered implements the heap property on data[lo:hi].
+// first is an offset into the array where the root of the heap lies.
+func siftDownOrdered[T constraints.Ordered](data []T, lo, hi, first int) {
+	root := lo
+	for {
+		child := 2*root + 1
+		if child >= hi {
+			return
+		}
+		if child+1 < hi && cmpLess(data[first+child], data[first+child+1]) {
+			child++
+		}
+		if !cmpLess(data[first+root], data[first+child]) {
+			return
+		}
+		data[first+root], data[first+child] = data[first+child], data[first+root]
+		root = child
+	}
+}
+
+// heapSortOrdered sorts data[a:b] using heapsort.
+func heapSortOrdered[T constraints.Ordered](data []T, a, b int) {
+	first := a
+	lo := 0
+	hi := b - a
+
+	// Build heap with greatest element at top.
+	for i := (hi - 1) / 2; i >= 0; i-- {
+		siftDownOrdered(data, i, hi, first)
+	}
+
+	// Pop elements, largest first, into end of data.
+	for i := hi - 1; i >= 0; i-- {
+		data[first], data[first+i] = data[first+i], data[first]
+		siftDownOrdered(data, lo, i, first)
+	}
+}
+
+// medianOfThreeOrdered moves the median of the three values
+// data[m0], data[m1], data[m2] into data[m1].
+func medianOfThreeOrdered[T constraints.Ordered](data []T, m1, m0, m2 int) {
+	// sort 3 elements
+	if cmpLess(data[m1], data[m0]) {
+		data[m1], data[m0] = data[m0], data[m1]
+	}
+	// data[m0] <= data[m1]
+	if cmpLess(data[m2], data[m1]) {
+		data[m2], data[m1] = data[m1], data[m2]
+		// data[m0] <= data[m2] && data[m1] < data[m2]
+		if cmpLess(data[m1], data[m0]) {
+			data[m1], data[m0] = data[m0], data[m1]
+		}
+	}
+	// now data[m0] <= data[m1] <= data[m2]
+}
+
+// partitionOrdered partitions data[a:b] around a median-of-three pivot and
+// returns the final position of the pivot. Elements equal to the pivot may
+// end up on either side, which keeps runs of duplicates balanced.
+func partitionOrdered[T constraints.Ordered](data []T, a, b int) int {
+	m := int(uint(a+b) >> 1)
+	medianOfThreeOrdered(data, a, m, b-1)
+	pivot := data[a]
+
+	i, j := a+1, b-1
+	for {
+		for i <= j && cmpLess(data[i], pivot) {
+			i++
+		}
+		for i <= j && cmpLess(pivot, data[j]) {
+			j--
+		}
+		if i >= j {
+			break
+		}
+		data[i], data[j] = data[j], data[i]
+		i++
+		j--
+	}
+	data[a], data[j] = data[j], data[a]
+	return j
+}
+
+// quickSortOrdered sorts data[a:b] using introsort: quicksort that falls
+// back to heapsort when the recursion gets too deep, and to insertion sort
+// for short ranges.
+func quickSortOrdered[T constraints.Ordered](data []T, a, b, maxDepth int) {
+	for b-a > 12 {
+		if maxDepth == 0 {
+			heapSortOrdered(data, a, b)
+			return
+		}
+		maxDepth--
+		p := partitionOrdered(data, a, b)
+		// Avoiding recursion on the larger subproblem guarantees
+		// a stack depth of at most lg(b-a).
+		if p-a < b-p {
+			quickSortOrdered(data, a, p, maxDepth)
+			a = p + 1
+		} else {
+			quickSortOrdered(data, p+1, b, maxDepth)
+			b = p
+		}
+	}
+	if b-a > 1 {
+		insertionSortOrdered(data, a, b)
+	}
+}
+
+// selectOrdered rearranges data[a:b] so that data[n] holds the element that
+// would be there if data[a:b] were sorted, using quickselect. Like
+// quickSortOrdered, it falls back to heapsort when partitioning degenerates.
+func selectOrdered[T constraints.Ordered](data []T, a, b, n, maxDepth int) {
+	for b-a > 12 {
+		if maxDepth == 0 {
+			heapSortOrdered(data, a, b)
+			return
+		}
+		maxDepth--
+		p := partitionOrdered(data, a, b)
+		switch {
+		case n < p:
+			b = p
+		case n > p:
+			a = p + 1
+		default:
+			return
+		}
+	}
+	insertionSortOrdered(data, a, b)
+}
+
+// insertionSortFunc sorts data[a:b] using insertion sort.
+func insertionSortFunc[T any](data []T, a, b int, less func(T, T) bool) {
+	for i := a + 1; i < b; i++ {
+		for j := i; j > a && less(data[j], data[j-1]); j-- {
+			data[j], data[j-1] = data[j-1], data[j]
+		}
+	}
+}
+
+// siftDownFunc implements the heap property on data[lo:hi].
+// first is an offset into the array where the root of the heap lies.
+func siftDownFunc[T any](data []T, lo, hi, first int, less func(T, T) bool) {
+	root := lo
+	for {
+		child := 2*root + 1
+		if child >= hi {
+			return
+		}
+		if child+1 < hi && less(data[first+child], data[first+child+1]) {
+			child++
+		}
+		if !less(data[first+root], data[first+child]) {
+			return
+		}
+		data[first+root], data[first+child] = data[first+child], data[first+root]
+		root = child
+	}
+}
+
+// heapSortFunc sorts data[a:b] using heapsort.
+func heapSortFunc[T any](data []T, a, b int, less func(T, T) bool) {
+	first := a
+	lo := 0
+	hi := b - a
+
+	// Build heap with greatest element at top.
+	for i := (hi - 1) / 2; i >= 0; i-- {
+		siftDownFunc(data, i, hi, first, less)
+	}
+
+	// Pop elements, largest first, into end of data.
+	for i := hi - 1; i >= 0; i-- {
+		data[first], data[first+i] = data[first+i], data[first]
+		siftDownFunc(data, lo, i, first, less)
+	}
+}
+
+// medianOfThreeFunc moves the median of the three values
+// data[m0], data[m1], data[m2] into data[m1].
+func medianOfThreeFunc[T any](data []T, m1, m0, m2 int, less func(T, T) bool) {
+	// sort 3 elements
+	if less(data[m1], data[m0]) {
+		data[m1], data[m0] = data[m0], data[m1]
+	}
+	// data[m0] <= data[m1]
+	if less(data[m2], data[m1]) {
+		data[m2], data[m1] = data[m1], data[m2]
+		// data[m0] <= data[m2] && data[m1] < data[m2]
+		if less(data[m1], data[m0]) {
+			data[m1], data[m0] = data[m0], data[m1]
+		}
+	}
+	// now data[m0] <= data[m1] <= data[m2]
+}
+
+// partitionFunc partitions data[a:b] around a median-of-three pivot and
+// returns the final position of the pivot. Elements equal to the pivot may
+// end up on either side, which keeps runs of duplicates balanced.
+func partitionFunc[T any](data []T, a, b int, less func(T, T) bool) int {
+	m := int(uint(a+b) >> 1)
+	medianOfThreeFunc(data, a, m, b-1, less)
+	pivot := data[a]
+
+	i, j := a+1, b-1
+	for {
+		for i <= j && less(data[i], pivot) {
+			i++
+		}
+		for i <= j && less(pivot, data[j]) {
+			j--
+		}
+		if i >= j {
+			break
+		}
+		data[i], data[j] = data[j], data[i]
+		i++
+		j--
+	}
+	data[a], data[j] = data[j], data[a]
+	return j
+}
+
+// quickSortFunc sorts data[a:b] using introsort: quicksort that falls
+// back to heapsort when the recursion gets too deep, and to insertion sort
+// for short ranges.
+func quickSortFunc[T any](data []T, a, b, maxDepth int, less func(T, T) bool) {
+	for b-a > 12 {
+		if maxDepth == 0 {
+			heapSortFunc(data, a, b, less)
+			return
+		}
+		maxDepth--
+		p := partitionFunc(data, a, b, less)
+		// Avoiding recursion on the larger subproblem guarantees
+		// a stack depth of at most lg(b-a).
+		if p-a < b-p {
+			quickSortFunc(data, a, p, maxDepth, less)
+			a = p + 1
+		} else {
+			quickSortFunc(data, p+1, b, maxDepth, less)
+			b = p
+		}
+	}
+	if b-a > 1 {
+		insertionSortFunc(data, a, b, less)
+	}
+}
+
+// selectFunc rearranges data[a:b] so that data[n] holds the element that
+// would be there if data[a:b] were sorted by less, using quickselect. Like
+// quickSortFunc, it falls back to heapsort when partitioning degenerates.
+func selectFunc[T any](data []T, a, b, n, maxDepth int, less func(T, T) bool) {
+	for b-a > 12 {
+		if maxDepth == 0 {
+			heapSortFunc(data, a, b, less)
+			return
+		}
+		maxDepth--
+		p := partitionFunc(data, a, b, less)
+		switch {
+		case n < p:
+			b = p
+		case n > p:
+			a = p + 1
+		default:
+			return
+		}
+	}
+	insertionSortFunc(data, a, b, less)
+}
+
+// stableFunc sorts data[0:n] stably: it insertion sorts blocks of a fixed
+// size and then repeatedly merges neighbouring blocks in place using
+// symMergeFunc. It performs O(n*log(n)) calls to less and O(n*log(n)*log(n))
+// swaps, but no allocations.
+func stableFunc[T any](data []T, n int, less func(T, T) bool) {
+	blockSize := 20 // must be > 0
+	a, b := 0, blockSize
+	for b <= n {
+		insertionSortFunc(data, a, b, less)
+		a = b
+		b += blockSize
+	}
+	insertionSortFunc(data, a, n, less)
+
+	for blockSize < n {
+		a, b = 0, 2*blockSize
+		for b <= n {
+			symMergeFunc(data, a, a+blockSize, b, less)
+			a = b
+			b += 2 * blockSize
+		}
+		if m := a + blockSize; m < n {
+			symMergeFunc(data, a, m, n, less)
+		}
+		blockSize *= 2
+	}
+}
+
+// symMergeFunc merges the two sorted subsequences data[a:m] and data[m:b]
+// using the SymMerge algorithm from Pok-Son Kim and Arne Kutzner,
+// "Stable Minimum Storage Merging by Symmetric Comparisons", in Susanne
+// Albers and Tomasz Radzik, editors, Algorithms - ESA 2004, volume 3221
+// of Lecture Notes in Computer Science, pages 714-723. Springer, 2004.
+func symMergeFunc[T any](data []T, a, m, b int, less func(T, T) bool) {
+	// Avoid unnecessary recursions of symMerge by direct insertion of
+	// data[a] into data[m:b] if data[a:m] only contains one element.
+	if m-a == 1 {
+		// Use binary search to find the lowest index i such that
+		// data[i] >= data[a] for m <= i < b.
+		i := m
+		j := b
+		for i < j {
+			h := int(uint(i+j) >> 1)
+			if less(data[h], data[a]) {
+				i = h + 1
+			} else {
+				j = h
+			}
+		}
+		// Swap values until data[a] reaches the position before i.
+		for k := a; k < i-1; k++ {
+			data[k], data[k+1] = data[k+1], data[k]
+		}
+		return
+	}
+
+	// Avoid unnecessary recursions of symMerge by direct insertion of
+	// data[m] into data[a:m] if data[m:b] only contains one element.
+	if b-m == 1 {
+		// Use binary search to find the lowest index i such that
+		// data[i] > data[m] for a <= i < m.
+		i := a
+		j := m
+		for i < j {
+			h := int(uint(i+j) >> 1)
+			if !less(data[m], data[h]) {
+				i = h + 1
+			} else {
+				j = h
+			}
+		}
+		// Swap values until data[m] reaches the position i.
+		for k := m; k > i; k-- {
+			data[k], data[k-1] = data[k-1], data[k]
+		}
+		return
+	}
+
+	mid := int(uint(a+b) >> 1)
+	n := mid + m
+	var start, r int
+	if m > mid {
+		start = n - b
+		r = mid
+	} else {
+		start = a
+		r = m
+	}
+	p := n - 1
+
+	for start < r {
+		c := int(uint(start+r) >> 1)
+		if !less(data[p-c], data[c]) {
+			start = c + 1
+		} else {
+			r = c
+		}
+	}
+
+	end := n - start
+	if start < m && m < end {
+		rotateRange(data, start, m, end)
+	}
+	if a < start && start < mid {
+		symMergeFunc(data, a, start, mid, less)
+	}
+	if mid < end && end < b {
+		symMergeFunc(data, mid, end, b, less)
+	}
+}
+
+// swapRange swaps the n elements starting at data[a] with the n elements
+// starting at data[b].
+func swapRange[T any](data []T, a, b, n int) {
+	for i := 0; i < n; i++ {
+		data[a+i], data[b+i] = data[b+i], data[a+i]
+	}
+}
+
+// rotateRange rotates two consecutive blocks u = data[a:m] and
+// v = data[m:b] in data: data of the form 'x u v y' is changed to
+// 'x v u y'. rotateRange performs at most b-a many calls to swapRange.
+func rotateRange[T any](data []T, a, m, b int) {
+	i := m - a
+	j := b - m
+
+	for i != j {
+		if i > j {
+			swapRange(data, m-i, m, j)
+			i -= j
+		} else {
+			swapRange(data, m-i, m+j-i, i)
+			j -= i
+		}
+	}
+	// i == j
+	swapRange(data, m-i, m, i)
+}
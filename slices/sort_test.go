@@ -0,0 +1,872 @@
+package slices
+
+import (
+	"math"
+	"math/rand"
+	"sort"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+)
+
+var ints = [...]int{74, 59, 238, -784, 9845, 959, 905, 0, 0, 42, 7586, -5467984, 7586}
+var float64s = [...]float64{74.3, 59.0, math.Inf(1), 238.2, -784.0, 2.3, math.Inf(-1), 9845.768, -959.7485, 905, 7.8, 7.8, 74.3}
+var float64sWithNaNs = [...]float64{74.3, 59.0, math.Inf(1), 238.2, -784.0, 2.3, math.NaN(), math.NaN(), math.Inf(-1), 9845.768, -959.7485, 905, 7.8, 7.8}
+var strs = [...]string{"", "Hello", "foo", "bar", "foo", "f00", "%*&^*&^&", "***"}
+
+func TestSortIntSlice(t *testing.T) {
+	data := ints
+	Sort(data[:])
+	if !sort.IntsAreSorted(data[:]) {
+		t.Errorf("sorted %v", ints)
+		t.Errorf("   got %v", data)
+	}
+}
+
+func TestSortFloat64Slice(t *testing.T) {
+	data := float64s
+	Sort(data[:])
+	if !sort.Float64sAreSorted(data[:]) {
+		t.Errorf("sorted %v", float64s)
+		t.Errorf("   got %v", data)
+	}
+}
+
+func TestSortFloat64SliceWithNaNs(t *testing.T) {
+	data := float64sWithNaNs
+	Sort(data[:])
+	// NaNs are ordered first.
+	nans := 0
+	for nans < len(data) && math.IsNaN(data[nans]) {
+		nans++
+	}
+	if nans != 2 {
+		t.Fatalf("got %d leading NaNs in %v, want 2", nans, data)
+	}
+	if !sort.Float64sAreSorted(data[nans:]) {
+		t.Errorf("sorted %v", float64sWithNaNs)
+		t.Errorf("   got %v", data)
+	}
+}
+
+func TestSortStringSlice(t *testing.T) {
+	data := strs
+	Sort(data[:])
+	if !sort.StringsAreSorted(data[:]) {
+		t.Errorf("sorted %v", strs)
+		t.Errorf("   got %v", data)
+	}
+}
+
+func TestSortNamedSlice(t *testing.T) {
+	type IDs []int
+	data := IDs{5, 2, 8, 1, 9, 3}
+	Sort(data)
+	if want := (IDs{1, 2, 3, 5, 8, 9}); !Equal(data, want) {
+		t.Errorf("Sort = %v, want %v", data, want)
+	}
+}
+
+func TestSortLarge(t *testing.T) {
+	r := rand.New(rand.NewSource(1))
+	for _, n := range []int{0, 1, 2, 13, 100, 1000, 100000} {
+		for _, mod := range []int{2, 100, math.MaxInt32} {
+			data := make([]int, n)
+			for i := range data {
+				data[i] = r.Intn(mod)
+			}
+			want := Clone(data)
+			sort.Ints(want)
+			Sort(data)
+			if !Equal(data, want) {
+				t.Errorf("Sort of %d elements mod %d is not sorted", n, mod)
+			}
+		}
+	}
+}
+
+func TestSortAllocs(t *testing.T) {
+	data := make([]int, 1000)
+	if n := testing.AllocsPerRun(10, func() {
+		for i := range data {
+			data[i] = len(data) - i
+		}
+		Sort(data)
+	}); n != 0 {
+		t.Errorf("Sort: got %v allocs, want 0", n)
+	}
+}
+
+func BenchmarkSortInts(b *testing.B) {
+	r := rand.New(rand.NewSource(1))
+	data := make([]int, 10000)
+	for i := 0; i < b.N; i++ {
+		b.StopTimer()
+		for j := range data {
+			data[j] = r.Int()
+		}
+		b.StartTimer()
+		Sort(data)
+	}
+}
+
+type person struct {
+	Name string
+	Age  int
+}
+
+func TestSortFuncMultipleKeys(t *testing.T) {
+	data := []person{
+		{"Gopher", 13},
+		{"Alice", 55},
+		{"Bob", 24},
+		{"Alice", 20},
+		{"Gopher", 7},
+		{"Bob", 24},
+	}
+	// Sort by name, then by age.
+	SortFunc(data, func(a, b person) bool {
+		if a.Name != b.Name {
+			return a.Name < b.Name
+		}
+		return a.Age < b.Age
+	})
+	want := []person{
+		{"Alice", 20},
+		{"Alice", 55},
+		{"Bob", 24},
+		{"Bob", 24},
+		{"Gopher", 7},
+		{"Gopher", 13},
+	}
+	if !Equal(data, want) {
+		t.Errorf("SortFunc = %v, want %v", data, want)
+	}
+}
+
+func TestSortFuncClosure(t *testing.T) {
+	rank := map[string]int{"gold": 0, "silver": 1, "bronze": 2}
+	data := []string{"bronze", "gold", "silver", "bronze", "gold"}
+	SortFunc(data, func(a, b string) bool { return rank[a] < rank[b] })
+	want := []string{"gold", "gold", "silver", "bronze", "bronze"}
+	if !Equal(data, want) {
+		t.Errorf("SortFunc = %v, want %v", data, want)
+	}
+}
+
+func TestSortFuncNonComparable(t *testing.T) {
+	data := [][]int{{3, 1}, {1}, {2, 2, 2}, {}}
+	SortFunc(data, func(a, b []int) bool { return len(a) < len(b) })
+	for i := 1; i < len(data); i++ {
+		if len(data[i-1]) > len(data[i]) {
+			t.Fatalf("SortFunc by length = %v, not sorted", data)
+		}
+	}
+}
+
+func TestSortFuncLarge(t *testing.T) {
+	r := rand.New(rand.NewSource(1))
+	data := make([]int, 10000)
+	for i := range data {
+		data[i] = r.Intn(1000)
+	}
+	want := Clone(data)
+	sort.Ints(want)
+	SortFunc(data, func(a, b int) bool { return a < b })
+	if !Equal(data, want) {
+		t.Errorf("SortFunc of random ints is not sorted")
+	}
+}
+
+// TestSortFuncInconsistent documents that an inconsistent less function
+// neither panics nor loses elements; the resulting order is unspecified.
+func TestSortFuncInconsistent(t *testing.T) {
+	r := rand.New(rand.NewSource(1))
+	data := make([]int, 1000)
+	for i := range data {
+		data[i] = i
+	}
+	SortFunc(data, func(a, b int) bool { return r.Intn(2) == 0 })
+	seen := make([]bool, len(data))
+	for _, v := range data {
+		if seen[v] {
+			t.Fatalf("SortFunc with inconsistent less duplicated element %d", v)
+		}
+		seen[v] = true
+	}
+}
+
+type logEntry struct {
+	Severity int
+	Index    int // position in the original slice
+}
+
+func TestSortStableFunc(t *testing.T) {
+	r := rand.New(rand.NewSource(1))
+	for _, n := range []int{0, 1, 2, 19, 20, 21, 100, 1000, 10000} {
+		data := make([]logEntry, n)
+		for i := range data {
+			data[i] = logEntry{Severity: r.Intn(5), Index: i}
+		}
+		SortStableFunc(data, func(a, b logEntry) bool { return a.Severity < b.Severity })
+		for i := 1; i < len(data); i++ {
+			prev, cur := data[i-1], data[i]
+			if prev.Severity > cur.Severity {
+				t.Fatalf("n=%d: not sorted at %d: %v before %v", n, i, prev, cur)
+			}
+			if prev.Severity == cur.Severity && prev.Index > cur.Index {
+				t.Fatalf("n=%d: not stable at %d: %v before %v", n, i, prev, cur)
+			}
+		}
+	}
+}
+
+func TestSortStableFuncAllEqual(t *testing.T) {
+	data := make([]logEntry, 100)
+	for i := range data {
+		data[i] = logEntry{Severity: 1, Index: i}
+	}
+	SortStableFunc(data, func(a, b logEntry) bool { return a.Severity < b.Severity })
+	for i, e := range data {
+		if e.Index != i {
+			t.Fatalf("SortStableFunc reordered equal elements: data[%d] = %v", i, e)
+		}
+	}
+}
+
+var isSortedTests = []struct {
+	name string
+	s    []int
+	want bool
+}{
+	{"nil", nil, true},
+	{"single", []int{1}, true},
+	{"ascending", []int{1, 2, 3, 4}, true},
+	{"descending", []int{4, 3, 2, 1}, false},
+	{"equal run", []int{1, 2, 2, 2, 3}, true},
+	{"all equal", []int{5, 5, 5}, true},
+	{"unsorted tail", []int{1, 2, 3, 0}, false},
+}
+
+func TestIsSorted(t *testing.T) {
+	for _, test := range isSortedTests {
+		if got := IsSorted(test.s); got != test.want {
+			t.Errorf("%s: IsSorted(%v) = %t, want %t", test.name, test.s, got, test.want)
+		}
+	}
+}
+
+func TestIsSortedFunc(t *testing.T) {
+	for _, test := range isSortedTests {
+		if got := IsSortedFunc(test.s, func(a, b int) bool { return a < b }); got != test.want {
+			t.Errorf("%s: IsSortedFunc(%v, <) = %t, want %t", test.name, test.s, got, test.want)
+		}
+	}
+	if !IsSortedFunc([]int{4, 3, 3, 1}, func(a, b int) bool { return a > b }) {
+		t.Errorf("IsSortedFunc with descending order = false, want true")
+	}
+}
+
+func TestIsSortedNaN(t *testing.T) {
+	nan := math.NaN()
+	for _, test := range []struct {
+		s    []float64
+		want bool
+	}{
+		{[]float64{nan}, true},
+		{[]float64{nan, nan, 1, 2}, true},
+		{[]float64{1, nan, 2}, false},
+		{[]float64{1, 2, nan}, false},
+	} {
+		if got := IsSorted(test.s); got != test.want {
+			t.Errorf("IsSorted(%v) = %t, want %t", test.s, got, test.want)
+		}
+	}
+	data := float64sWithNaNs
+	Sort(data[:])
+	if !IsSorted(data[:]) {
+		t.Errorf("IsSorted(%v) = false after Sort", data)
+	}
+}
+
+func TestMonotonic(t *testing.T) {
+	nan := math.NaN()
+	for _, tt := range []struct {
+		s                                    []float64
+		strictInc, nonDec, strictDec, nonInc bool
+	}{
+		{nil, true, true, true, true},
+		{[]float64{1}, true, true, true, true},
+		{[]float64{1, 2, 3}, true, true, false, false},
+		{[]float64{1, 2, 2, 3}, false, true, false, false},
+		{[]float64{3, 2, 1}, false, false, true, true},
+		{[]float64{3, 2, 2, 1}, false, false, false, true},
+		{[]float64{2, 2}, false, true, false, true},
+		{[]float64{1, 3, 2}, false, false, false, false},
+		{[]float64{nan, 1, 2}, true, true, false, false},
+		{[]float64{nan, nan, 1}, false, true, false, false},
+		{[]float64{2, 1, nan}, false, false, true, true},
+	} {
+		if got := IsStrictlyIncreasing(tt.s); got != tt.strictInc {
+			t.Errorf("IsStrictlyIncreasing(%v) = %t, want %t", tt.s, got, tt.strictInc)
+		}
+		if got := IsNonDecreasing(tt.s); got != tt.nonDec {
+			t.Errorf("IsNonDecreasing(%v) = %t, want %t", tt.s, got, tt.nonDec)
+		}
+		if got := IsStrictlyDecreasing(tt.s); got != tt.strictDec {
+			t.Errorf("IsStrictlyDecreasing(%v) = %t, want %t", tt.s, got, tt.strictDec)
+		}
+		if got := IsNonIncreasing(tt.s); got != tt.nonInc {
+			t.Errorf("IsNonIncreasing(%v) = %t, want %t", tt.s, got, tt.nonInc)
+		}
+	}
+}
+
+func TestBinarySearch(t *testing.T) {
+	str1 := []string{"foo"}
+	str2 := []string{"ab", "ca"}
+	str3 := []string{"mo", "qo", "vo"}
+	str4 := []string{"ab", "ad", "ca", "xy"}
+
+	// slice with repeating elements
+	strRepeats := []string{"ba", "ca", "da", "da", "da", "ka", "ma", "ma", "ta"}
+
+	// slice with all element equal
+	strSame := []string{"xx", "xx", "xx"}
+
+	tests := []struct {
+		data      []string
+		target    string
+		wantPos   int
+		wantFound bool
+	}{
+		{[]string{}, "foo", 0, false},
+		{[]string{}, "", 0, false},
+
+		{str1, "foo", 0, true},
+		{str1, "bar", 0, false},
+		{str1, "zx", 1, false},
+
+		{str2, "aa", 0, false},
+		{str2, "ab", 0, true},
+		{str2, "ad", 1, false},
+		{str2, "ca", 1, true},
+		{str2, "ra", 2, false},
+
+		{str3, "bb", 0, false},
+		{str3, "mo", 0, true},
+		{str3, "nb", 1, false},
+		{str3, "qo", 1, true},
+		{str3, "tr", 2, false},
+		{str3, "vo", 2, true},
+		{str3, "xr", 3, false},
+
+		{str4, "aa", 0, false},
+		{str4, "ab", 0, true},
+		{str4, "ac", 1, false},
+		{str4, "ad", 1, true},
+		{str4, "ax", 2, false},
+		{str4, "ca", 2, true},
+		{str4, "cc", 3, false},
+		{str4, "dd", 3, false},
+		{str4, "xy", 3, true},
+		{str4, "zz", 4, false},
+
+		{strRepeats, "da", 2, true},
+		{strRepeats, "db", 5, false},
+		{strRepeats, "ma", 6, true},
+		{strRepeats, "mb", 8, false},
+
+		{strSame, "xx", 0, true},
+		{strSame, "ab", 0, false},
+		{strSame, "zz", 3, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.target, func(t *testing.T) {
+			pos, found := BinarySearch(tt.data, tt.target)
+			if pos != tt.wantPos || found != tt.wantFound {
+				t.Errorf("BinarySearch(%v, %q) = %d, %v, want %d, %v", tt.data, tt.target, pos, found, tt.wantPos, tt.wantFound)
+			}
+		})
+	}
+}
+
+func TestBinarySearchInts(t *testing.T) {
+	data := []int{20, 30, 40, 50, 60, 70, 80, 90}
+	tests := []struct {
+		target    int
+		wantPos   int
+		wantFound bool
+	}{
+		{20, 0, true},
+		{23, 1, false},
+		{43, 3, false},
+		{80, 6, true},
+		{-1, 0, false},
+		{100, 8, false},
+	}
+	for _, tt := range tests {
+		pos, found := BinarySearch(data, tt.target)
+		if pos != tt.wantPos || found != tt.wantFound {
+			t.Errorf("BinarySearch(%d) = %d, %v, want %d, %v", tt.target, pos, found, tt.wantPos, tt.wantFound)
+		}
+	}
+}
+
+func TestBinarySearchFloats(t *testing.T) {
+	data := []float64{math.NaN(), -0.25, 0.0, 1.4}
+	tests := []struct {
+		target    float64
+		wantPos   int
+		wantFound bool
+	}{
+		{math.NaN(), 0, true},
+		{math.Inf(-1), 1, false},
+		{-0.25, 1, true},
+		{0.0, 2, true},
+		{1.4, 3, true},
+		{1.5, 4, false},
+	}
+	for _, tt := range tests {
+		pos, found := BinarySearch(data, tt.target)
+		if pos != tt.wantPos || found != tt.wantFound {
+			t.Errorf("BinarySearch(%v) = %d, %v, want %d, %v", tt.target, pos, found, tt.wantPos, tt.wantFound)
+		}
+	}
+}
+
+type user struct {
+	ID   string
+	Name string
+}
+
+func TestBinarySearchFunc(t *testing.T) {
+	users := []user{
+		{"a01", "Alice"},
+		{"b07", "Bob"},
+		{"b07", "Bobby"},
+		{"g42", "Gopher"},
+	}
+	byID := func(u user, id string) int {
+		switch {
+		case u.ID < id:
+			return -1
+		case u.ID > id:
+			return 1
+		}
+		return 0
+	}
+	tests := []struct {
+		id        string
+		wantPos   int
+		wantFound bool
+	}{
+		{"a00", 0, false},
+		{"a01", 0, true},
+		{"b07", 1, true},
+		{"c00", 3, false},
+		{"g42", 3, true},
+		{"z99", 4, false},
+	}
+	for _, tt := range tests {
+		pos, found := BinarySearchFunc(users, tt.id, byID)
+		if pos != tt.wantPos || found != tt.wantFound {
+			t.Errorf("BinarySearchFunc(%q) = %d, %v, want %d, %v", tt.id, pos, found, tt.wantPos, tt.wantFound)
+		}
+	}
+	if pos, found := BinarySearchFunc([]user(nil), "a01", byID); pos != 0 || found {
+		t.Errorf("BinarySearchFunc(nil) = %d, %v, want 0, false", pos, found)
+	}
+}
+
+// TestBinarySearchFuncCaseInsensitive uses a slice that is sorted under cmp
+// but whose matching elements are not == to the target.
+func TestBinarySearchFuncCaseInsensitive(t *testing.T) {
+	data := []string{"apple", "Banana", "cherry", "DATE"}
+	cmp := func(a, b string) int {
+		a, b = strings.ToLower(a), strings.ToLower(b)
+		switch {
+		case a < b:
+			return -1
+		case a > b:
+			return 1
+		}
+		return 0
+	}
+	if pos, found := BinarySearchFunc(data, "banana", cmp); pos != 1 || !found {
+		t.Errorf("BinarySearchFunc(banana) = %d, %v, want 1, true", pos, found)
+	}
+	if pos, found := BinarySearchFunc(data, "Date", cmp); pos != 3 || !found {
+		t.Errorf("BinarySearchFunc(Date) = %d, %v, want 3, true", pos, found)
+	}
+	if pos, found := BinarySearch(data, "banana"); found {
+		t.Errorf("BinarySearch(banana) = %d, %v, want not found", pos, found)
+	}
+}
+
+func TestInsertOrdered(t *testing.T) {
+	for _, tt := range []struct {
+		s    []int
+		v    int
+		want []int
+	}{
+		{nil, 1, []int{1}},
+		{[]int{2, 3}, 1, []int{1, 2, 3}},
+		{[]int{1, 2}, 3, []int{1, 2, 3}},
+		{[]int{1, 3}, 2, []int{1, 2, 3}},
+		{[]int{1, 2, 2, 3}, 2, []int{1, 2, 2, 2, 3}},
+	} {
+		s := append([]int(nil), tt.s...)
+		if got := InsertOrdered(s, tt.v); !Equal(got, tt.want) {
+			t.Errorf("InsertOrdered(%v, %d) = %v, want %v", tt.s, tt.v, got, tt.want)
+		}
+	}
+
+	var s []int
+	for _, v := range rand.New(rand.NewSource(1)).Perm(100) {
+		s = InsertOrdered(s, v%10)
+	}
+	if !IsSorted(s) || len(s) != 100 {
+		t.Errorf("InsertOrdered did not keep the slice sorted: %v", s)
+	}
+
+	nan := math.NaN()
+	if got := InsertOrdered([]float64{nan, 1, 2}, 1.5); !IsSorted(got) {
+		t.Errorf("InsertOrdered with NaN = %v, not sorted", got)
+	}
+}
+
+func TestInsertOrderedFunc(t *testing.T) {
+	byAge := func(a, b person) int { return a.Age - b.Age }
+	s := []person{{"a", 10}, {"b", 20}, {"c", 20}, {"d", 30}}
+	want := []person{{"a", 10}, {"b", 20}, {"c", 20}, {"e", 20}, {"d", 30}}
+	if got := InsertOrderedFunc(s, person{"e", 20}, byAge); !Equal(got, want) {
+		t.Errorf("InsertOrderedFunc = %v, want %v", got, want)
+	}
+	if got := InsertOrderedFunc([]person(nil), person{"a", 1}, byAge); !Equal(got, []person{{"a", 1}}) {
+		t.Errorf("InsertOrderedFunc(nil) = %v", got)
+	}
+	if got := InsertOrderedFunc([]person{{"a", 1}}, person{"z", 0}, byAge); !Equal(got, []person{{"z", 0}, {"a", 1}}) {
+		t.Errorf("InsertOrderedFunc at front = %v", got)
+	}
+}
+
+func TestMergeSorted(t *testing.T) {
+	for _, tt := range []struct {
+		a, b []int
+		want []int
+	}{
+		{nil, nil, []int{}},
+		{[]int{1, 2}, nil, []int{1, 2}},
+		{nil, []int{1, 2}, []int{1, 2}},
+		{[]int{1, 3, 5}, []int{2, 4, 6}, []int{1, 2, 3, 4, 5, 6}},
+		{[]int{1, 2}, []int{3, 4}, []int{1, 2, 3, 4}},
+		{[]int{3, 4}, []int{1, 2}, []int{1, 2, 3, 4}},
+		{[]int{1, 1}, []int{1}, []int{1, 1, 1}},
+	} {
+		if got := MergeSorted(tt.a, tt.b); !Equal(got, tt.want) {
+			t.Errorf("MergeSorted(%v, %v) = %v, want %v", tt.a, tt.b, got, tt.want)
+		}
+	}
+
+	r := rand.New(rand.NewSource(1))
+	a, b := make([]int, 500), make([]int, 300)
+	for i := range a {
+		a[i] = r.Intn(100)
+	}
+	for i := range b {
+		b[i] = r.Intn(100)
+	}
+	Sort(a)
+	Sort(b)
+	want := append(append([]int(nil), a...), b...)
+	Sort(want)
+	if got := MergeSorted(a, b); !Equal(got, want) {
+		t.Errorf("MergeSorted of random slices is not the sorted concatenation")
+	}
+}
+
+func TestMergeSortedFuncStable(t *testing.T) {
+	byAge := func(a, b person) bool { return a.Age < b.Age }
+	a := []person{{"a1", 10}, {"a2", 20}, {"a3", 20}}
+	b := []person{{"b1", 10}, {"b2", 20}, {"b3", 30}}
+	want := []person{{"a1", 10}, {"b1", 10}, {"a2", 20}, {"a3", 20}, {"b2", 20}, {"b3", 30}}
+	if got := MergeSortedFunc(a, b, byAge); !Equal(got, want) {
+		t.Errorf("MergeSortedFunc = %v, want %v", got, want)
+	}
+}
+
+func benchmarkMergeInput() (a, b []int) {
+	r := rand.New(rand.NewSource(1))
+	a, b = make([]int, 5000), make([]int, 5000)
+	for i := range a {
+		a[i], b[i] = r.Int(), r.Int()
+	}
+	Sort(a)
+	Sort(b)
+	return a, b
+}
+
+func BenchmarkMergeSorted(b *testing.B) {
+	s1, s2 := benchmarkMergeInput()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		MergeSorted(s1, s2)
+	}
+}
+
+func BenchmarkMergeSortedAppendSort(b *testing.B) {
+	s1, s2 := benchmarkMergeInput()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		s := make([]int, 0, len(s1)+len(s2))
+		s = append(append(s, s1...), s2...)
+		Sort(s)
+	}
+}
+
+func TestMaxNMinN(t *testing.T) {
+	r := rand.New(rand.NewSource(1))
+	for _, n := range []int{0, 1, 10, 100, 1000} {
+		s := make([]int, n)
+		for i := range s {
+			s[i] = r.Intn(50)
+		}
+		orig := append([]int(nil), s...)
+		asc := append([]int(nil), s...)
+		Sort(asc)
+		desc := append([]int(nil), asc...)
+		Reverse(desc)
+		for _, k := range []int{0, 1, 3, n / 2, n - 1, n, n + 5} {
+			if k < 0 {
+				continue
+			}
+			wantK := k
+			if wantK > n {
+				wantK = n
+			}
+			if got := MaxN(s, k); !Equal(got, desc[:wantK]) {
+				t.Errorf("MaxN(n=%d, k=%d) = %v, want %v", n, k, got, desc[:wantK])
+			}
+			if got := MinN(s, k); !Equal(got, asc[:wantK]) {
+				t.Errorf("MinN(n=%d, k=%d) = %v, want %v", n, k, got, asc[:wantK])
+			}
+			less := func(a, b int) bool { return a < b }
+			if got := MaxNFunc(s, k, less); !Equal(got, desc[:wantK]) {
+				t.Errorf("MaxNFunc(n=%d, k=%d) = %v, want %v", n, k, got, desc[:wantK])
+			}
+			if got := MinNFunc(s, k, less); !Equal(got, asc[:wantK]) {
+				t.Errorf("MinNFunc(n=%d, k=%d) = %v, want %v", n, k, got, asc[:wantK])
+			}
+		}
+		if !Equal(s, orig) {
+			t.Errorf("MaxN/MinN modified their input")
+		}
+	}
+}
+
+func TestMaxNNaN(t *testing.T) {
+	nan := math.NaN()
+	s := []float64{nan, 3, 1, nan, 2, 5, 4, 0, 6, 7}
+	if got := MaxN(s, 3); !Equal(got, []float64{7, 6, 5}) {
+		t.Errorf("MaxN with NaNs = %v, want [7 6 5]", got)
+	}
+	if got := MinN(s, 3); len(got) != 3 || !math.IsNaN(got[0]) || !math.IsNaN(got[1]) || got[2] != 0 {
+		t.Errorf("MinN with NaNs = %v, want [NaN NaN 0]", got)
+	}
+}
+
+func TestMaxNPanics(t *testing.T) {
+	defer func() {
+		if recover() == nil {
+			t.Errorf("MaxN with negative k did not panic")
+		}
+	}()
+	MaxN([]int{1}, -1)
+}
+
+func BenchmarkMaxN(b *testing.B) {
+	r := rand.New(rand.NewSource(1))
+	data := make([]int, 100000)
+	for i := range data {
+		data[i] = r.Int()
+	}
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		MaxN(data, 10)
+	}
+}
+
+func BenchmarkMaxNSortCopy(b *testing.B) {
+	r := rand.New(rand.NewSource(1))
+	data := make([]int, 100000)
+	for i := range data {
+		data[i] = r.Int()
+	}
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		s := Clone(data)
+		Sort(s)
+		Reverse(s[len(s)-10:])
+	}
+}
+
+func TestSelectNth(t *testing.T) {
+	r := rand.New(rand.NewSource(1))
+	for iter := 0; iter < 200; iter++ {
+		n := 1 + r.Intn(200)
+		s := make([]int, n)
+		for i := range s {
+			s[i] = r.Intn(n)
+		}
+		orig := append([]int(nil), s...)
+		sorted := append([]int(nil), s...)
+		Sort(sorted)
+		for _, k := range []int{0, n - 1, r.Intn(n)} {
+			if got := SelectNth(s, k); got != sorted[k] {
+				t.Fatalf("SelectNth(%v, %d) = %d, want %d", s, k, got, sorted[k])
+			}
+			if got := SelectNthFunc(s, k, func(a, b int) bool { return a < b }); got != sorted[k] {
+				t.Fatalf("SelectNthFunc(%v, %d) = %d, want %d", s, k, got, sorted[k])
+			}
+		}
+		if !Equal(s, orig) {
+			t.Fatalf("SelectNth modified its input")
+		}
+
+		k := r.Intn(n)
+		got := SelectNthInPlace(s, k)
+		if got != sorted[k] || s[k] != got {
+			t.Fatalf("SelectNthInPlace(%v, %d) = %d, want %d", orig, k, got, sorted[k])
+		}
+		for i := range s {
+			if (i < k && s[i] > got) || (i > k && s[i] < got) {
+				t.Fatalf("SelectNthInPlace(%v, %d) left %v unpartitioned", orig, k, s)
+			}
+		}
+	}
+}
+
+func TestSelectNthFuncInPlace(t *testing.T) {
+	s := []person{{"a", 30}, {"b", 10}, {"c", 20}}
+	got := SelectNthFuncInPlace(s, 1, func(a, b person) bool { return a.Age < b.Age })
+	if got.Name != "c" || s[1].Name != "c" {
+		t.Errorf("SelectNthFuncInPlace = %v, s = %v", got, s)
+	}
+}
+
+func TestSelectNthPanics(t *testing.T) {
+	for _, n := range []int{-1, 3} {
+		func() {
+			defer func() {
+				if recover() == nil {
+					t.Errorf("SelectNth(s, %d) did not panic", n)
+				}
+			}()
+			SelectNth([]int{1, 2, 3}, n)
+		}()
+	}
+}
+
+type event struct {
+	Name string
+	At   time.Time
+}
+
+func TestSortBy(t *testing.T) {
+	people := []person{{"carol", 30}, {"alice", 25}, {"bob", 30}}
+	SortBy(people, func(p person) string { return p.Name })
+	if want := []person{{"alice", 25}, {"bob", 30}, {"carol", 30}}; !Equal(people, want) {
+		t.Errorf("SortBy(Name) = %v, want %v", people, want)
+	}
+
+	base := time.Date(2022, 1, 1, 0, 0, 0, 0, time.UTC)
+	events := []event{
+		{"c", base.Add(3 * time.Hour)},
+		{"a", base.Add(time.Hour)},
+		{"b", base.Add(2 * time.Hour)},
+	}
+	byTime := func(e event) int64 { return e.At.UnixNano() }
+	SortBy(events, byTime)
+	if got := Map(events, func(e event) string { return e.Name }); !Equal(got, []string{"a", "b", "c"}) {
+		t.Errorf("SortBy(At) = %v, want [a b c]", got)
+	}
+	SortByDesc(events, byTime)
+	if got := Map(events, func(e event) string { return e.Name }); !Equal(got, []string{"c", "b", "a"}) {
+		t.Errorf("SortByDesc(At) = %v, want [c b a]", got)
+	}
+
+	r := rand.New(rand.NewSource(1))
+	large := make([]person, 1000)
+	for i := range large {
+		large[i] = person{strconv.Itoa(r.Int()), r.Intn(100)}
+	}
+	calls := 0
+	SortBy(large, func(p person) int { calls++; return p.Age })
+	if !IsSortedFunc(large, func(a, b person) bool { return a.Age < b.Age }) {
+		t.Errorf("SortBy(Age) did not sort by age")
+	}
+	if limit := 4 * 1000 * 10 * 2; calls > limit {
+		t.Errorf("SortBy called key %d times, want at most %d", calls, limit)
+	}
+}
+
+func TestSortStableBy(t *testing.T) {
+	people := []person{{"d", 30}, {"a", 25}, {"c", 30}, {"b", 25}}
+	SortStableBy(people, func(p person) int { return p.Age })
+	if want := []person{{"a", 25}, {"b", 25}, {"d", 30}, {"c", 30}}; !Equal(people, want) {
+		t.Errorf("SortStableBy(Age) = %v, want %v", people, want)
+	}
+}
+
+func TestArgSort(t *testing.T) {
+	data := ints
+	s := data[:]
+	idx := ArgSort(s)
+	if !Equal(s, ints[:]) {
+		t.Errorf("ArgSort modified its input")
+	}
+	sorted := ApplyPermutation(s, idx)
+	if !IsSorted(sorted) || len(sorted) != len(s) {
+		t.Errorf("ApplyPermutation(s, ArgSort(s)) = %v, not sorted", sorted)
+	}
+
+	// Equal elements keep their original order.
+	if got := ArgSort([]int{2, 1, 2, 1}); !Equal(got, []int{1, 3, 0, 2}) {
+		t.Errorf("ArgSort([2 1 2 1]) = %v, want [1 3 0 2]", got)
+	}
+	if got := ArgSort([]int(nil)); len(got) != 0 {
+		t.Errorf("ArgSort(nil) = %v, want []", got)
+	}
+}
+
+func TestArgSortFuncParallel(t *testing.T) {
+	names := []string{"carol", "alice", "bob"}
+	ages := []int{30, 25, 35}
+	idx := ArgSortFunc(names, func(a, b string) bool { return a < b })
+	if got := ApplyPermutation(names, idx); !Equal(got, []string{"alice", "bob", "carol"}) {
+		t.Errorf("ApplyPermutation(names) = %v", got)
+	}
+	if got := ApplyPermutation(ages, idx); !Equal(got, []int{25, 35, 30}) {
+		t.Errorf("ApplyPermutation(ages) = %v", got)
+	}
+}
+
+func TestApplyPermutationPanics(t *testing.T) {
+	for _, idx := range [][]int{
+		{0, 1},
+		{0, 1, 1},
+		{0, 1, 3},
+		{-1, 0, 1},
+	} {
+		func() {
+			defer func() {
+				if recover() == nil {
+					t.Errorf("ApplyPermutation(%v) did not panic", idx)
+				}
+			}()
+			ApplyPermutation([]int{1, 2, 3}, idx)
+		}()
+	}
+}